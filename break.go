@@ -2,39 +2,177 @@ package surfforecast
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/ztimes2/surfforecast-go/internal/htmlutil"
 	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
 const (
 	pathSearchBreaks = "/breaks/ac_location_name"
 	pathFormatBreak  = "/breaks/%s"
+	pathPrefixBreaks = "/breaks/"
 
 	queryParamSearchQuery = "query"
 )
 
+// MinSearchQueryLength is the minimum number of characters of a search query that
+// www.surf-forecast.com's surf break search responds to with results. Shorter
+// queries get no results, and its responses to them vary.
+const MinSearchQueryLength = 2
+
 const (
 	idDropFormControlNav   = "dropformcont-nav"
 	idCountry              = "country_id"
 	idLocationFilenamePart = "location_filename_part"
 
+	classBreakHeaderPhoto = "break-header__photo"
+	classBreakHeaderMap   = "break-header__map"
+	classBreakDetails     = "break-details"
+
 	attributeSelected = "selected"
 )
 
 var (
 	// ErrBreakNotFound indicates that a surf break could not be found.
 	ErrBreakNotFound = errors.New("break not found")
+
+	// ErrBreakHasNoForecast indicates that a surf break has no pages of its own
+	// anymore, for instance when it has been decommissioned and www.surf-forecast.com
+	// redirects to a region page instead.
+	ErrBreakHasNoForecast = errors.New("break has no forecast")
+
+	// ErrEmptyQuery indicates that a search query is empty or consists of
+	// whitespace only.
+	ErrEmptyQuery = errors.New("empty query")
 )
 
 // SearchBreaks searches for surf breaks by the given text query.
+//
+// ErrEmptyQuery is returned without sending a request when the query is empty or
+// consists of whitespace only. Queries shorter than MinSearchQueryLength result in
+// no surf breaks without sending a request either.
 func (s *Scraper) SearchBreaks(query string) ([]Break, error) {
+	return s.searchBreaks(context.Background(), query)
+}
+
+// SearchBreaksMany searches for surf breaks by each of the given text queries
+// concurrently, running at most the given number of searches at a time. The
+// results are keyed by the queries. A surf break that matches several queries is
+// listed only under the first of them in the given order.
+//
+// The searches stop as soon as one of them fails or the given context is done. An
+// empty query fails with ErrEmptyQuery just like with SearchBreaks.
+func (s *Scraper) SearchBreaksMany(
+	ctx context.Context,
+	queries []string,
+	concurrency int) (map[string][]Break, error) {
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		results   = make([][]Break, len(queries))
+		semaphore = make(chan struct{}, concurrency)
+		wg        sync.WaitGroup
+
+		mu       sync.Mutex
+		firstErr error
+	)
+
+loop:
+	for i, query := range queries {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			break loop
+		}
+
+		wg.Add(1)
+		go func(i int, query string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			breaks, err := s.searchBreaks(ctx, query)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("could not search breaks by %q: %w", query, err)
+					cancel()
+				}
+				mu.Unlock()
+				return
+			}
+
+			results[i] = breaks
+		}(i, query)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var (
+		breaksByQuery = make(map[string][]Break, len(queries))
+		seen          = make(map[breakKey]bool)
+	)
+	for i, query := range queries {
+		if _, ok := breaksByQuery[query]; ok {
+			continue
+		}
+
+		breaks := []Break{}
+		for _, b := range results[i] {
+			key := breakKey{name: b.Name, countryName: b.CountryName}
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+			breaks = append(breaks, b)
+		}
+
+		breaksByQuery[query] = breaks
+	}
+
+	return breaksByQuery, nil
+}
+
+// breakKey identifies a surf break among search results.
+type breakKey struct {
+	name        string
+	countryName string
+}
+
+func (s *Scraper) searchBreaks(ctx context.Context, query string) ([]Break, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrEmptyQuery
+	}
+	if utf8.RuneCountInString(query) < MinSearchQueryLength {
+		return []Break{}, nil
+	}
+
 	u, err := url.Parse(s.baseURL + pathSearchBreaks)
 	if err != nil {
 		return nil, fmt.Errorf("could not prepare request url: %w", err)
@@ -44,24 +182,72 @@ func (s *Scraper) SearchBreaks(query string) ([]Break, error) {
 	vals.Add(queryParamSearchQuery, query)
 	u.RawQuery = vals.Encode()
 
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	req, err := s.newRequest(ctx, u.String())
 	if err != nil {
-		return nil, fmt.Errorf("could not prepare request: %w", err)
+		return nil, &ScrapeError{
+			Endpoint: pathSearchBreaks,
+			Stage:    StageFetch,
+			Err:      fmt.Errorf("could not prepare request: %w", err),
+		}
 	}
 
-	resp, err := s.httpClient.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
-		return nil, fmt.Errorf("could not send request: %w", err)
+		return nil, &ScrapeError{Endpoint: pathSearchBreaks, Stage: StageFetch, Err: err}
 	}
 
+	defer resp.Body.Close()
+
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("received response with %d status code", resp.StatusCode)
+		return nil, &ScrapeError{
+			Endpoint:   pathSearchBreaks,
+			StatusCode: resp.StatusCode,
+			Stage:      StageFetch,
+			Err:        fmt.Errorf("received response with %d status code", resp.StatusCode),
+		}
 	}
 
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
+	r, err := decompress(resp)
 	if err != nil {
-		return nil, fmt.Errorf("could not read response body: %w", err)
+		return nil, &ScrapeError{
+			Endpoint:   pathSearchBreaks,
+			StatusCode: resp.StatusCode,
+			Stage:      StageFetch,
+			Err:        fmt.Errorf("could not decompress response body: %w", err),
+		}
+	}
+
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, &ScrapeError{
+			Endpoint:   pathSearchBreaks,
+			StatusCode: resp.StatusCode,
+			Stage:      StageFetch,
+			Err:        fmt.Errorf("could not read response body: %w", err),
+		}
+	}
+
+	breaks, err := ParseBreakSearchResults(body)
+	if err != nil {
+		return nil, &ScrapeError{
+			Endpoint:   pathSearchBreaks,
+			StatusCode: resp.StatusCode,
+			Stage:      StageSearchResults,
+			Err:        fmt.Errorf("could not parse response body: %w", err),
+		}
+	}
+
+	return breaks, nil
+}
+
+// ParseBreakSearchResults parses surf breaks from the given body of a response of
+// www.surf-forecast.com's surf break search endpoint, for instance when the
+// response has been obtained by other means than SearchBreaks.
+//
+// An empty body results in no surf breaks.
+func ParseBreakSearchResults(body []byte) ([]Break, error) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return []Break{}, nil
 	}
 
 	// The search response's payload contains a 2D JSON-alike array of strings
@@ -75,22 +261,26 @@ func (s *Scraper) SearchBreaks(query string) ([]Break, error) {
 
 	var results [][]string
 	if err := json.Unmarshal(body, &results); err != nil {
-		return nil, fmt.Errorf("could not unmarshal response body: %w", err)
+		return nil, fmt.Errorf("could not unmarshal search results: %w", err)
 	}
 
 	var breaks []Break
 	for _, result := range results {
-		if len(result) != 3 {
-			return nil, fmt.Errorf("unexpected search result")
+		if len(result) < 3 {
+			return nil, fmt.Errorf("unexpected search result: %q", result)
 		}
 
 		// The result's first element contains some alpha-numerical string, but
-		// I have no clue what it represents. Therefore, it is ignored here. 
+		// I have no clue what it represents. Therefore, it is ignored here.
 		// ¯\_(ツ)_/¯
+		//
+		// Some results come with extra elements, so the name is taken from the
+		// second element and the country from the last one, and anything in
+		// between is ignored.
 
 		breaks = append(breaks, Break{
 			Name:        result[1],
-			CountryName: result[2],
+			CountryName: result[len(result)-1],
 		})
 	}
 
@@ -101,46 +291,135 @@ func (s *Scraper) SearchBreaks(query string) ([]Break, error) {
 type Break struct {
 	Name        string
 	CountryName string
+
+	// PhotoURL holds an absolute URL of the surf break's photo. It is empty when
+	// the surf break has no photo.
+	PhotoURL string
+
+	// MapImageURL holds an absolute URL of the surf break's location map thumbnail.
+	// It is empty when the surf break has no map thumbnail.
+	MapImageURL string
+
+	// Type, BestSwellDirection, BestWindDirection, BestTide and IdealSwellSize
+	// hold the texts of the surf break's details panel as they are displayed by
+	// www.surf-forecast.com, for instance "Reef break", "SW", "E", "Mid tide" and
+	// "1.5m". A field is empty when the panel does not list it or lists it as
+	// unknown.
+	Type               string
+	BestSwellDirection string
+	BestWindDirection  string
+	BestTide           string
+	IdealSwellSize     string
+
+	// ResponseHeaders holds the headers of the response the surf break was scraped
+	// from. It is nil unless Scraper is configured using WithExposeResponseHeaders.
+	ResponseHeaders http.Header
+}
+
+// slugReplacements maps the accented letters commonly found in the names of surf
+// breaks to their unaccented equivalents.
+var slugReplacements = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a", "å", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o", "ø", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ñ", "n", "ç", "c", "ß", "ss",
+	"Á", "A", "À", "A", "Â", "A", "Ä", "A", "Ã", "A", "Å", "A",
+	"É", "E", "È", "E", "Ê", "E", "Ë", "E",
+	"Í", "I", "Ì", "I", "Î", "I", "Ï", "I",
+	"Ó", "O", "Ò", "O", "Ô", "O", "Ö", "O", "Õ", "O", "Ø", "O",
+	"Ú", "U", "Ù", "U", "Û", "U", "Ü", "U",
+	"Ñ", "N", "Ç", "C",
+)
+
+// SlugForName guesses the name of a surf break that www.surf-forecast.com uses in
+// its URLs, and that the methods of Scraper expect, from the given display name,
+// for instance "Cherating-Beach" for "Cherating Beach". Accented letters are
+// replaced with unaccented ones, words are joined with hyphens, and characters
+// other than letters, digits, hyphens and underscores are dropped. The capitalization
+// is kept as is.
+//
+// The site's rules are not documented, so the result is a best-effort guess that
+// might not match the actual name, for instance when the site disambiguates surf
+// breaks with the same name. Break can be used for verifying the guess, which fails
+// with ErrBreakNotFound for a wrong one.
+func SlugForName(name string) string {
+	name = slugReplacements.Replace(name)
+
+	var words []string
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-' || r == '/'
+	}) {
+		word = strings.Map(func(r rune) rune {
+			if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_') {
+				return r
+			}
+			return -1
+		}, word)
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+
+	return strings.Join(words, "-")
+}
+
+// FetchBreakHTML returns the raw HTML page of the given surf break specified by
+// its name without scraping it. It is meant for debugging scraping failures and
+// capturing pages as regression fixtures.
+//
+// ErrBreakNotFound is returned when the given surf break does not exist, and
+// ErrBreakHasNoForecast is returned when it redirects to a region page.
+func (s *Scraper) FetchBreakHTML(ctx context.Context, breakName string) ([]byte, error) {
+	body, _, err := s.fetch(ctx, fmt.Sprintf(pathFormatBreak, breakName))
+	return body, err
 }
 
 // Break returns a surf break by its name.
 //
-// ErrBreakNotFound is returned when the given surf break does not exist.
+// ErrBreakNotFound is returned when the given surf break does not exist, and
+// ErrBreakHasNoForecast is returned when it redirects to a region page.
 func (s *Scraper) Break(breakName string) (Break, error) {
 	path := fmt.Sprintf(pathFormatBreak, breakName)
 
-	req, err := http.NewRequest(http.MethodGet, s.baseURL+path, nil)
+	node, header, err := s.fetchPage(context.Background(), path)
 	if err != nil {
-		return Break{}, fmt.Errorf("could not prepare request: %w", err)
+		return Break{}, err
 	}
 
-	resp, err := s.httpClient.Do(req)
+	brk, err := s.ScrapeBreak(node)
 	if err != nil {
-		return Break{}, fmt.Errorf("could not send request: %w", err)
+		return Break{}, withEndpoint(err, path)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		if resp.StatusCode == http.StatusNotFound {
-			return Break{}, ErrBreakNotFound
-		}
-		return Break{}, fmt.Errorf("received response with %d status code", resp.StatusCode)
-	}
+	brk.ResponseHeaders = s.responseHeaders(header)
 
-	defer resp.Body.Close()
-	node, err := html.Parse(resp.Body)
+	return brk, nil
+}
+
+// ScrapeBreak scrapes a surf break from the given surf break page that has already
+// been fetched and parsed, for instance a page saved to a file. It is the same
+// scraping logic that Break uses after fetching the page. Failures are returned as
+// ScrapeError.
+func (s *Scraper) ScrapeBreak(n *html.Node) (Break, error) {
+	base, err := url.Parse(s.baseURL)
 	if err != nil {
-		return Break{}, fmt.Errorf("could not parse response body as html: %w", err)
+		return Break{}, &ScrapeError{
+			Stage: StageBreak,
+			Err:   fmt.Errorf("could not parse base url: %w", err),
+		}
 	}
 
-	brk, err := scrapeBreak(node)
+	brk, err := scrapeBreak(n, base)
 	if err != nil {
-		return Break{}, fmt.Errorf("could not scrape break: %w", err)
+		return Break{}, &ScrapeError{Stage: StageBreak, Err: err}
 	}
 
 	return brk, nil
 }
 
-func scrapeBreak(n *html.Node) (Break, error) {
+func scrapeBreak(n *html.Node, base *url.URL) (Break, error) {
 	navNode, ok := htmlutil.FindOne(n, htmlutil.WithIDEqual(idDropFormControlNav))
 	if !ok {
 		return Break{}, errors.New("could not find navigation node")
@@ -176,8 +455,119 @@ func scrapeBreak(n *html.Node) (Break, error) {
 		return Break{}, errors.New("could not find break name text node")
 	}
 
-	return Break{
+	photoURL, err := scrapeImageURL(n, classBreakHeaderPhoto, base)
+	if err != nil {
+		return Break{}, fmt.Errorf("could not scrape photo url: %w", err)
+	}
+
+	mapImageURL, err := scrapeImageURL(n, classBreakHeaderMap, base)
+	if err != nil {
+		return Break{}, fmt.Errorf("could not scrape map image url: %w", err)
+	}
+
+	brk := Break{
 		Name:        breakNameTextNode.Data,
 		CountryName: countryNameTextNode.Data,
-	}, nil
+		PhotoURL:    photoURL,
+		MapImageURL: mapImageURL,
+	}
+	scrapeBreakDetails(n, &brk)
+
+	return brk, nil
+}
+
+// breakDetailFields maps the lowercase labels of the surf break's details panel to
+// the fields of Break they are scraped into.
+var breakDetailFields = map[string]func(*Break) *string{
+	"type":                 func(b *Break) *string { return &b.Type },
+	"type of wave":         func(b *Break) *string { return &b.Type },
+	"break type":           func(b *Break) *string { return &b.Type },
+	"best swell direction": func(b *Break) *string { return &b.BestSwellDirection },
+	"best wind direction":  func(b *Break) *string { return &b.BestWindDirection },
+	"best wind":            func(b *Break) *string { return &b.BestWindDirection },
+	"best tide":            func(b *Break) *string { return &b.BestTide },
+	"best tide position":   func(b *Break) *string { return &b.BestTide },
+	"ideal swell size":     func(b *Break) *string { return &b.IdealSwellSize },
+	"best swell size":      func(b *Break) *string { return &b.IdealSwellSize },
+}
+
+// scrapeBreakDetails scrapes the surf break's details panel into the given Break.
+// The panel lists its details as label cells followed by their value cells, either
+// as table cells of the same row or as terms of a description list, so the values
+// are found by their labels rather than by their positions. Values of "unknown"
+// are left empty, and so are the fields of a missing panel.
+func scrapeBreakDetails(n *html.Node, brk *Break) {
+	panelNode, ok := htmlutil.FindOne(n, htmlutil.WithClassContaining(classBreakDetails))
+	if !ok {
+		return
+	}
+
+	htmlutil.ForEach(panelNode, func(n *html.Node) error {
+		if n.Type != html.ElementNode || !isBreakDetailLabel(n.DataAtom) {
+			return nil
+		}
+
+		valueNode, ok := htmlutil.NextElementSibling(n)
+		if !ok || !isBreakDetailValue(valueNode.DataAtom) {
+			return nil
+		}
+
+		label := strings.ToLower(strings.TrimSuffix(collapsedText(n), ":"))
+		field, ok := breakDetailFields[strings.TrimSpace(label)]
+		if !ok || *field(brk) != "" {
+			return nil
+		}
+
+		value := collapsedText(valueNode)
+		if strings.EqualFold(value, "unknown") {
+			return nil
+		}
+
+		*field(brk) = value
+		return nil
+	})
+}
+
+// isBreakDetailLabel checks if an element of the given type can hold a label of
+// the surf break's details panel.
+func isBreakDetailLabel(a atom.Atom) bool {
+	return a == atom.Th || a == atom.Td || a == atom.Dt
+}
+
+// isBreakDetailValue checks if an element of the given type can hold a value of
+// the surf break's details panel. Header cells cannot, so that a header row of
+// labels is not mistaken for labels and their values.
+func isBreakDetailValue(a atom.Atom) bool {
+	return a == atom.Td || a == atom.Dd
+}
+
+// scrapeImageURL scrapes the source of an image that is placed within a node with
+// the given class, and resolves it against the given base URL. An empty string
+// is returned when there is no such image.
+func scrapeImageURL(n *html.Node, class string, base *url.URL) (string, error) {
+	containerNode, ok := htmlutil.FindOne(n, htmlutil.WithClassContaining(class))
+	if !ok {
+		return "", nil
+	}
+
+	imageNode, ok := htmlutil.FindOne(
+		containerNode,
+		htmlutil.WithElement(atom.Img),
+		htmlutil.WithAttribute(htmlutil.AttributeSource),
+	)
+	if !ok {
+		return "", nil
+	}
+
+	srcAttr, _ := htmlutil.Attribute(imageNode, htmlutil.AttributeSource)
+	if srcAttr.Val == "" {
+		return "", nil
+	}
+
+	u, err := url.Parse(srcAttr.Val)
+	if err != nil {
+		return "", fmt.Errorf("invalid image url: %q", srcAttr.Val)
+	}
+
+	return base.ResolveReference(u).String(), nil
 }
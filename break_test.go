@@ -0,0 +1,167 @@
+package surfforecast
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestScrapeBreakDetails(t *testing.T) {
+	tests := []struct {
+		name string
+		page string
+		want Break
+	}{
+		{
+			name: "table",
+			page: `
+				<p>Type</p><p>Outside of the panel</p>
+				<div class="break-details">
+					<table>
+						<tr><th>Type</th><th>Rating</th></tr>
+						<tr><th>Type:</th><td>Reef</td></tr>
+						<tr><td>Best swell direction</td><td> South  West </td></tr>
+						<tr><td>Best wind direction</td><td>Unknown</td></tr>
+						<tr><td>Ideal swell size</td><td>1.5m</td></tr>
+					</table>
+				</div>`,
+			want: Break{
+				Type:               "Reef",
+				BestSwellDirection: "South West",
+				IdealSwellSize:     "1.5m",
+			},
+		},
+		{
+			name: "description list",
+			page: `
+				<dl class="break-details__list">
+					<dt>Type of wave</dt><dd>Point</dd>
+					<dt>Best tide</dt><dd>Mid</dd>
+				</dl>`,
+			want: Break{
+				Type:     "Point",
+				BestTide: "Mid",
+			},
+		},
+		{
+			name: "missing panel",
+			page: `<table><tr><th>Type</th><td>Reef</td></tr></table>`,
+			want: Break{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Break
+			scrapeBreakDetails(mustParseHTML(t, tt.page), &got)
+
+			if got.Type != tt.want.Type ||
+				got.BestSwellDirection != tt.want.BestSwellDirection ||
+				got.BestWindDirection != tt.want.BestWindDirection ||
+				got.BestTide != tt.want.BestTide ||
+				got.IdealSwellSize != tt.want.IdealSwellSize {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlugForName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Uluwatu", want: "Uluwatu"},
+		{name: "Cherating Beach", want: "Cherating-Beach"},
+		{name: "  Padang   Padang ", want: "Padang-Padang"},
+		{name: "Praia do Guincho", want: "Praia-do-Guincho"},
+		{name: "Hossegor - La Gravière", want: "Hossegor-La-Graviere"},
+		{name: "Mundaka/Bizkaia", want: "Mundaka-Bizkaia"},
+		{name: "Sainte-Barbe's Reef", want: "Sainte-Barbes-Reef"},
+		{name: "Playa Peñíscola", want: "Playa-Peniscola"},
+		{name: "Großes Riff", want: "Grosses-Riff"},
+		{name: "Break_2", want: "Break_2"},
+		{name: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := SlugForName(tt.name); got != tt.want {
+			t.Errorf("%q: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseBreakSearchResults(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []Break
+		wantErr bool
+	}{
+		{
+			name: "results",
+			body: `[['1','Uluwatu','Indonesia'],['2','Padang Padang','Indonesia']]`,
+			want: []Break{
+				{Name: "Uluwatu", CountryName: "Indonesia"},
+				{Name: "Padang Padang", CountryName: "Indonesia"},
+			},
+		},
+		{
+			name: "extra elements",
+			body: `[['1','Uluwatu','Bali','Indonesia'],['2','Kuta','Bali','Lombok','Indonesia']]`,
+			want: []Break{
+				{Name: "Uluwatu", CountryName: "Indonesia"},
+				{Name: "Kuta", CountryName: "Indonesia"},
+			},
+		},
+		{name: "no results", body: `[]`},
+		{name: "empty body", body: " \n", want: []Break{}},
+		{name: "too few elements", body: `[['1','Uluwatu']]`, wantErr: true},
+		{name: "malformed", body: `<html>`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBreakSearchResults([]byte(tt.body))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("got %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].Name != tt.want[i].Name || got[i].CountryName != tt.want[i].CountryName {
+					t.Errorf("result %d: got %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestScraper_SearchBreaks_Gzip(t *testing.T) {
+	s := New(
+		WithBaseURL(newFixtureServer(t).URL),
+		// Requesting the compression explicitly stops the HTTP client from
+		// decompressing the response on its own.
+		WithRequestDecorator(func(r *http.Request) error {
+			r.Header.Set("Accept-Encoding", "gzip")
+			return nil
+		}),
+	)
+
+	breaks, err := s.SearchBreaks("Uluwatu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(breaks) != 2 {
+		t.Fatalf("got %d surf breaks, want 2", len(breaks))
+	}
+	if breaks[0].Name != "Uluwatu" {
+		t.Errorf("got %q, want %q", breaks[0].Name, "Uluwatu")
+	}
+}
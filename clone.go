@@ -0,0 +1,55 @@
+package surfforecast
+
+// Clone returns a deep copy of the forecast, so that the copy can be modified
+// without affecting the original forecast.
+func (f *Forecast) Clone() *Forecast {
+	if f == nil {
+		return nil
+	}
+
+	clone := *f
+	if f.ResponseHeaders != nil {
+		clone.ResponseHeaders = f.ResponseHeaders.Clone()
+	}
+	if f.Daily != nil {
+		clone.Daily = make([]*DailyForecast, len(f.Daily))
+		for i, d := range f.Daily {
+			clone.Daily[i] = d.Clone()
+		}
+	}
+
+	return &clone
+}
+
+// Clone returns a deep copy of the daily forecast. See Forecast.Clone for details.
+func (d *DailyForecast) Clone() *DailyForecast {
+	if d == nil {
+		return nil
+	}
+
+	clone := *d
+	if d.Hourly != nil {
+		clone.Hourly = make([]HourlyForecast, len(d.Hourly))
+		for i, h := range d.Hourly {
+			clone.Hourly[i] = h.Clone()
+		}
+	}
+	if d.Tides != nil {
+		clone.Tides = append([]Tide{}, d.Tides...)
+	}
+
+	return &clone
+}
+
+// Clone returns a deep copy of the hourly forecast. See Forecast.Clone for details.
+func (h HourlyForecast) Clone() HourlyForecast {
+	clone := h
+	if h.Swells.Secondary != nil {
+		clone.Swells.Secondary = append([]Swell{}, h.Swells.Secondary...)
+	}
+	if h.Raw != nil {
+		raw := *h.Raw
+		clone.Raw = &raw
+	}
+	return clone
+}
@@ -0,0 +1,148 @@
+package surfforecast
+
+import (
+	"math"
+	"strings"
+)
+
+// CompassPoint is one of the 16 points of the compass, for instance "NNW", as it
+// is displayed by www.surf-forecast.com.
+type CompassPoint string
+
+// The 16 points of the compass.
+const (
+	North          CompassPoint = "N"
+	NorthNorthEast CompassPoint = "NNE"
+	NorthEast      CompassPoint = "NE"
+	EastNorthEast  CompassPoint = "ENE"
+	East           CompassPoint = "E"
+	EastSouthEast  CompassPoint = "ESE"
+	SouthEast      CompassPoint = "SE"
+	SouthSouthEast CompassPoint = "SSE"
+	South          CompassPoint = "S"
+	SouthSouthWest CompassPoint = "SSW"
+	SouthWest      CompassPoint = "SW"
+	WestSouthWest  CompassPoint = "WSW"
+	West           CompassPoint = "W"
+	WestNorthWest  CompassPoint = "WNW"
+	NorthWest      CompassPoint = "NW"
+	NorthNorthWest CompassPoint = "NNW"
+)
+
+// compassPointDegrees maps the compass points to the directions they represent in
+// degrees clockwise from north.
+var compassPointDegrees = map[CompassPoint]float64{
+	North:          0,
+	NorthNorthEast: 22.5,
+	NorthEast:      45,
+	EastNorthEast:  67.5,
+	East:           90,
+	EastSouthEast:  112.5,
+	SouthEast:      135,
+	SouthSouthEast: 157.5,
+	South:          180,
+	SouthSouthWest: 202.5,
+	SouthWest:      225,
+	WestSouthWest:  247.5,
+	West:           270,
+	WestNorthWest:  292.5,
+	NorthWest:      315,
+	NorthNorthWest: 337.5,
+}
+
+// compassPoints holds the compass points starting from north and going clockwise.
+var compassPoints = []CompassPoint{
+	North, NorthNorthEast, NorthEast, EastNorthEast,
+	East, EastSouthEast, SouthEast, SouthSouthEast,
+	South, SouthSouthWest, SouthWest, WestSouthWest,
+	West, WestNorthWest, NorthWest, NorthNorthWest,
+}
+
+// arrows holds the arrows pointing in the 8 principal directions starting from
+// north and going clockwise.
+var arrows = []rune{'↑', '↗', '→', '↘', '↓', '↙', '←', '↖'}
+
+// ParseCompassPoint converts the given text, for instance a swell's or a wind's
+// DirectionFromInCompassPoints, to CompassPoint. The conversion is case-insensitive
+// and ignores surrounding whitespace.
+func ParseCompassPoint(s string) CompassPoint {
+	return CompassPoint(strings.ToUpper(strings.TrimSpace(s)))
+}
+
+// Degrees returns the direction the compass point represents in degrees clockwise
+// from north. False is returned when the compass point is unknown.
+func (p CompassPoint) Degrees() (float64, bool) {
+	degrees, ok := compassPointDegrees[p]
+	return degrees, ok
+}
+
+// Arrow returns an arrow glyph pointing in the direction of the compass point,
+// rounded clockwise to one of the 8 principal directions, for instance '↗' for
+// "NNE" and "NE". '?' is returned when the compass point is unknown.
+func (p CompassPoint) Arrow() rune {
+	degrees, ok := p.Degrees()
+	if !ok {
+		return '?'
+	}
+
+	i := int(math.Round(degrees/45)) % len(arrows)
+	return arrows[i]
+}
+
+// DegreesToCompassPoint returns the compass point closest to the given direction
+// in degrees clockwise from north. Each compass point covers 22.5 degrees centered
+// on its own direction including the lower boundary, so for instance 11.25 degrees
+// is "NNE" and 348.75 degrees is "N". Degrees outside of [0, 360) are wrapped.
+func DegreesToCompassPoint(degrees float64) CompassPoint {
+	sector := 360.0 / float64(len(compassPoints))
+
+	// Shifting by half of a sector makes each compass point's sector start at
+	// a multiple of the sector's size.
+	shifted := math.Mod(degrees+sector/2, 360)
+	if shifted < 0 {
+		shifted += 360
+	}
+
+	return compassPoints[int(shifted/sector)%len(compassPoints)]
+}
+
+// CompassPointToDegrees returns the direction of the given compass point in
+// degrees clockwise from north. False is returned when the compass point is
+// unknown. See ParseCompassPoint for the accepted formats.
+func CompassPointToDegrees(s string) (float64, bool) {
+	return ParseCompassPoint(s).Degrees()
+}
+
+// angleBetween returns the smallest angle between both directions in degrees,
+// ranging from 0 to 180.
+func angleBetween(a, b float64) float64 {
+	diff := math.Abs(math.Mod(a-b, 360))
+	if diff > 180 {
+		diff = 360 - diff
+	}
+	return diff
+}
+
+// SwellDirections counts the compass points the primary and secondary swells of
+// all hourly forecasts of the day come from, keyed by the compass points
+// normalized by ParseCompassPoint, for instance "SW". Swells whose direction is
+// not a known compass point are left out.
+func (d DailyForecast) SwellDirections() map[string]int {
+	directions := make(map[string]int)
+
+	count := func(s Swell) {
+		p := ParseCompassPoint(s.DirectionFromInCompassPoints)
+		if _, ok := p.Degrees(); ok {
+			directions[string(p)]++
+		}
+	}
+
+	for _, h := range d.Hourly {
+		count(h.Swells.Primary)
+		for _, s := range h.Swells.Secondary {
+			count(s)
+		}
+	}
+
+	return directions
+}
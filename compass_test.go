@@ -0,0 +1,31 @@
+package surfforecast
+
+import "testing"
+
+func TestDegreesToCompassPoint(t *testing.T) {
+	tests := []struct {
+		degrees float64
+		want    CompassPoint
+	}{
+		{degrees: 0, want: North},
+		{degrees: 11.24, want: North},
+		{degrees: 11.25, want: NorthNorthEast},
+		{degrees: 33.74, want: NorthNorthEast},
+		{degrees: 33.75, want: NorthEast},
+		{degrees: 180, want: South},
+		{degrees: 326.25, want: NorthNorthWest},
+		{degrees: 348.74, want: NorthNorthWest},
+		{degrees: 348.75, want: North},
+		{degrees: 359.99, want: North},
+		{degrees: 360, want: North},
+		{degrees: 371.25, want: NorthNorthEast},
+		{degrees: -11.25, want: North},
+		{degrees: -11.26, want: NorthNorthWest},
+	}
+
+	for _, tt := range tests {
+		if got := DegreesToCompassPoint(tt.degrees); got != tt.want {
+			t.Errorf("%v: got %q, want %q", tt.degrees, got, tt.want)
+		}
+	}
+}
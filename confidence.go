@@ -0,0 +1,115 @@
+package surfforecast
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ztimes2/surfforecast-go/internal/htmlutil"
+	"golang.org/x/net/html"
+)
+
+const (
+	dataRowNameConfidence = "confidence"
+)
+
+// Confidence is a level of confidence that www.surf-forecast.com has in a daily
+// forecast based on how much the underlying forecast models agree with each other.
+type Confidence int
+
+const (
+	// ConfidenceUnknown indicates that the site does not display the confidence
+	// of a forecast, which is usually the case for the near-term days, or that
+	// it displays a confidence that is not recognised.
+	ConfidenceUnknown Confidence = iota
+	// ConfidenceLow indicates that the forecast models disagree a lot.
+	ConfidenceLow
+	// ConfidenceMedium indicates that the forecast models partially agree.
+	ConfidenceMedium
+	// ConfidenceHigh indicates that the forecast models mostly agree.
+	ConfidenceHigh
+)
+
+// String returns a human-readable name of the confidence.
+func (c Confidence) String() string {
+	switch c {
+	case ConfidenceLow:
+		return "low"
+	case ConfidenceMedium:
+		return "medium"
+	case ConfidenceHigh:
+		return "high"
+	default:
+		return "unknown"
+	}
+}
+
+// scrapeConfidences scrapes the confidence row into a confidence per day. Since
+// the row might display a confidence per each hour, the lowest one of the day is
+// taken. Confidences that are not recognised are logged and treated as
+// ConfidenceUnknown. Nil is returned when the table has no confidence row, unless
+// strict scraping is enabled, in which case an error is returned.
+func (s *Scraper) scrapeConfidences(t forecastTable) ([]Confidence, error) {
+	confidencesNode, ok := t.rows[dataRowNameConfidence]
+	if !ok {
+		if s.strictScraping {
+			return nil, errors.New("could not find confidences node")
+		}
+		return nil, nil
+	}
+
+	var (
+		confidences []Confidence
+		confidence  = ConfidenceUnknown
+	)
+	if err := t.forEachCell(confidencesNode, func(n *html.Node) error {
+		c, err := scrapeConfidence(n)
+		if err != nil {
+			s.logger.Printf("could not scrape confidence: %v", err)
+		}
+
+		if c != ConfidenceUnknown && (confidence == ConfidenceUnknown || c < confidence) {
+			confidence = c
+		}
+
+		isDayEnd := htmlutil.ClassContains(n, classIsDayEnd)
+		if isDayEnd {
+			confidences = append(confidences, confidence)
+			confidence = ConfidenceUnknown
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return confidences, nil
+}
+
+func scrapeConfidence(n *html.Node) (Confidence, error) {
+	var ss []string
+	htmlutil.ForEach(n, func(n *html.Node) error {
+		if n.Type == html.TextNode {
+			ss = append(ss, n.Data)
+		}
+		return nil
+	})
+
+	return parseConfidence(strings.Join(ss, " "))
+}
+
+// parseConfidence parses the given confidence text case-insensitively. An empty
+// text results in ConfidenceUnknown.
+func parseConfidence(s string) (Confidence, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "":
+		return ConfidenceUnknown, nil
+	case "low":
+		return ConfidenceLow, nil
+	case "medium":
+		return ConfidenceMedium, nil
+	case "high":
+		return ConfidenceHigh, nil
+	default:
+		return ConfidenceUnknown, fmt.Errorf("invalid confidence: %q", s)
+	}
+}
@@ -0,0 +1,57 @@
+package surfforecast
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestScraper_ScrapeConfidences(t *testing.T) {
+	table := mustIndexForecastTable(t, `
+		<tr class="forecast-table__row" data-row-name="confidence">
+			<td class="forecast-table__cell">High</td>
+			<td class="forecast-table__cell is-day-end">Medium</td>
+			<td class="forecast-table__cell">75%</td>
+			<td class="forecast-table__cell is-day-end"></td>
+			<td class="forecast-table__cell is-day-end">LOW</td>
+		</tr>`)
+
+	var logs bytes.Buffer
+	s := New(WithLogger(log.New(&logs, "", 0)))
+
+	got, err := s.scrapeConfidences(table)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []Confidence{ConfidenceMedium, ConfidenceUnknown, ConfidenceLow}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("day %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if !strings.Contains(logs.String(), `"75%"`) {
+		t.Errorf("unrecognised confidence has not been logged: %q", logs.String())
+	}
+}
+
+func TestScraper_ScrapeConfidences_MissingRow(t *testing.T) {
+	table := mustIndexForecastTable(t, `
+		<tr class="forecast-table__row" data-row-name="rating">
+			<td class="forecast-table__cell is-day-end"><img alt="3"></td>
+		</tr>`)
+
+	got, err := New().scrapeConfidences(table)
+	if err != nil || got != nil {
+		t.Errorf("got %v and %v, want nil confidences without error", got, err)
+	}
+
+	if _, err := New(WithStrictScraping(true)).scrapeConfidences(table); err == nil {
+		t.Error("got nil error in strict mode")
+	}
+}
@@ -0,0 +1,238 @@
+package surfforecast
+
+import "strings"
+
+// Continent is a continent that a surf break's country belongs to.
+type Continent string
+
+// Continents that surf breaks belong to.
+const (
+	ContinentAfrica       Continent = "Africa"
+	ContinentAntarctica   Continent = "Antarctica"
+	ContinentAsia         Continent = "Asia"
+	ContinentEurope       Continent = "Europe"
+	ContinentNorthAmerica Continent = "North America"
+	ContinentOceania      Continent = "Oceania"
+	ContinentSouthAmerica Continent = "South America"
+)
+
+// continents maps lowercase country names to continents. Central America and the
+// Caribbean belong to North America, and Pacific islands belong to Oceania.
+var continents = map[string]Continent{
+	// Africa
+	"algeria":                          ContinentAfrica,
+	"angola":                           ContinentAfrica,
+	"benin":                            ContinentAfrica,
+	"cameroon":                         ContinentAfrica,
+	"canary islands":                   ContinentAfrica,
+	"cape verde":                       ContinentAfrica,
+	"comoros":                          ContinentAfrica,
+	"congo":                            ContinentAfrica,
+	"democratic republic of the congo": ContinentAfrica,
+	"djibouti":                         ContinentAfrica,
+	"egypt":                            ContinentAfrica,
+	"equatorial guinea":                ContinentAfrica,
+	"eritrea":                          ContinentAfrica,
+	"gabon":                            ContinentAfrica,
+	"gambia":                           ContinentAfrica,
+	"ghana":                            ContinentAfrica,
+	"guinea":                           ContinentAfrica,
+	"guinea-bissau":                    ContinentAfrica,
+	"ivory coast":                      ContinentAfrica,
+	"kenya":                            ContinentAfrica,
+	"liberia":                          ContinentAfrica,
+	"libya":                            ContinentAfrica,
+	"madagascar":                       ContinentAfrica,
+	"mauritania":                       ContinentAfrica,
+	"mauritius":                        ContinentAfrica,
+	"mayotte":                          ContinentAfrica,
+	"morocco":                          ContinentAfrica,
+	"mozambique":                       ContinentAfrica,
+	"namibia":                          ContinentAfrica,
+	"nigeria":                          ContinentAfrica,
+	"reunion":                          ContinentAfrica,
+	"réunion":                          ContinentAfrica,
+	"sao tome and principe":            ContinentAfrica,
+	"senegal":                          ContinentAfrica,
+	"seychelles":                       ContinentAfrica,
+	"sierra leone":                     ContinentAfrica,
+	"somalia":                          ContinentAfrica,
+	"south africa":                     ContinentAfrica,
+	"sudan":                            ContinentAfrica,
+	"tanzania":                         ContinentAfrica,
+	"togo":                             ContinentAfrica,
+	"tunisia":                          ContinentAfrica,
+	"western sahara":                   ContinentAfrica,
+
+	// Antarctica
+	"antarctica": ContinentAntarctica,
+
+	// Asia
+	"bahrain":              ContinentAsia,
+	"bangladesh":           ContinentAsia,
+	"brunei":               ContinentAsia,
+	"cambodia":             ContinentAsia,
+	"china":                ContinentAsia,
+	"east timor":           ContinentAsia,
+	"hong kong":            ContinentAsia,
+	"india":                ContinentAsia,
+	"indonesia":            ContinentAsia,
+	"iran":                 ContinentAsia,
+	"israel":               ContinentAsia,
+	"japan":                ContinentAsia,
+	"jordan":               ContinentAsia,
+	"kuwait":               ContinentAsia,
+	"lebanon":              ContinentAsia,
+	"malaysia":             ContinentAsia,
+	"maldives":             ContinentAsia,
+	"myanmar":              ContinentAsia,
+	"north korea":          ContinentAsia,
+	"oman":                 ContinentAsia,
+	"pakistan":             ContinentAsia,
+	"philippines":          ContinentAsia,
+	"qatar":                ContinentAsia,
+	"russia":               ContinentAsia,
+	"saudi arabia":         ContinentAsia,
+	"singapore":            ContinentAsia,
+	"south korea":          ContinentAsia,
+	"sri lanka":            ContinentAsia,
+	"syria":                ContinentAsia,
+	"taiwan":               ContinentAsia,
+	"thailand":             ContinentAsia,
+	"timor-leste":          ContinentAsia,
+	"turkey":               ContinentAsia,
+	"united arab emirates": ContinentAsia,
+	"vietnam":              ContinentAsia,
+	"yemen":                ContinentAsia,
+
+	// Europe
+	"albania":          ContinentEurope,
+	"azores":           ContinentEurope,
+	"belgium":          ContinentEurope,
+	"bulgaria":         ContinentEurope,
+	"channel islands":  ContinentEurope,
+	"croatia":          ContinentEurope,
+	"cyprus":           ContinentEurope,
+	"denmark":          ContinentEurope,
+	"england":          ContinentEurope,
+	"estonia":          ContinentEurope,
+	"faroe islands":    ContinentEurope,
+	"finland":          ContinentEurope,
+	"france":           ContinentEurope,
+	"germany":          ContinentEurope,
+	"greece":           ContinentEurope,
+	"iceland":          ContinentEurope,
+	"ireland":          ContinentEurope,
+	"isle of man":      ContinentEurope,
+	"italy":            ContinentEurope,
+	"latvia":           ContinentEurope,
+	"lithuania":        ContinentEurope,
+	"madeira":          ContinentEurope,
+	"malta":            ContinentEurope,
+	"montenegro":       ContinentEurope,
+	"netherlands":      ContinentEurope,
+	"northern ireland": ContinentEurope,
+	"norway":           ContinentEurope,
+	"poland":           ContinentEurope,
+	"portugal":         ContinentEurope,
+	"romania":          ContinentEurope,
+	"scotland":         ContinentEurope,
+	"slovenia":         ContinentEurope,
+	"spain":            ContinentEurope,
+	"sweden":           ContinentEurope,
+	"uk":               ContinentEurope,
+	"ukraine":          ContinentEurope,
+	"united kingdom":   ContinentEurope,
+	"wales":            ContinentEurope,
+
+	// North America
+	"anguilla":                         ContinentNorthAmerica,
+	"antigua and barbuda":              ContinentNorthAmerica,
+	"aruba":                            ContinentNorthAmerica,
+	"bahamas":                          ContinentNorthAmerica,
+	"barbados":                         ContinentNorthAmerica,
+	"belize":                           ContinentNorthAmerica,
+	"bermuda":                          ContinentNorthAmerica,
+	"british virgin islands":           ContinentNorthAmerica,
+	"canada":                           ContinentNorthAmerica,
+	"cayman islands":                   ContinentNorthAmerica,
+	"costa rica":                       ContinentNorthAmerica,
+	"cuba":                             ContinentNorthAmerica,
+	"curacao":                          ContinentNorthAmerica,
+	"dominica":                         ContinentNorthAmerica,
+	"dominican republic":               ContinentNorthAmerica,
+	"el salvador":                      ContinentNorthAmerica,
+	"greenland":                        ContinentNorthAmerica,
+	"grenada":                          ContinentNorthAmerica,
+	"guadeloupe":                       ContinentNorthAmerica,
+	"guatemala":                        ContinentNorthAmerica,
+	"haiti":                            ContinentNorthAmerica,
+	"honduras":                         ContinentNorthAmerica,
+	"jamaica":                          ContinentNorthAmerica,
+	"martinique":                       ContinentNorthAmerica,
+	"mexico":                           ContinentNorthAmerica,
+	"nicaragua":                        ContinentNorthAmerica,
+	"panama":                           ContinentNorthAmerica,
+	"puerto rico":                      ContinentNorthAmerica,
+	"saint kitts and nevis":            ContinentNorthAmerica,
+	"saint lucia":                      ContinentNorthAmerica,
+	"saint vincent and the grenadines": ContinentNorthAmerica,
+	"trinidad and tobago":              ContinentNorthAmerica,
+	"turks and caicos islands":         ContinentNorthAmerica,
+	"united states":                    ContinentNorthAmerica,
+	"us virgin islands":                ContinentNorthAmerica,
+	"usa":                              ContinentNorthAmerica,
+
+	// Oceania
+	"american samoa":           ContinentOceania,
+	"australia":                ContinentOceania,
+	"cook islands":             ContinentOceania,
+	"fiji":                     ContinentOceania,
+	"french polynesia":         ContinentOceania,
+	"guam":                     ContinentOceania,
+	"hawaii":                   ContinentOceania,
+	"kiribati":                 ContinentOceania,
+	"marshall islands":         ContinentOceania,
+	"micronesia":               ContinentOceania,
+	"new caledonia":            ContinentOceania,
+	"new zealand":              ContinentOceania,
+	"niue":                     ContinentOceania,
+	"northern mariana islands": ContinentOceania,
+	"palau":                    ContinentOceania,
+	"papua new guinea":         ContinentOceania,
+	"samoa":                    ContinentOceania,
+	"solomon islands":          ContinentOceania,
+	"tahiti":                   ContinentOceania,
+	"tonga":                    ContinentOceania,
+	"tuvalu":                   ContinentOceania,
+	"vanuatu":                  ContinentOceania,
+
+	// South America
+	"argentina":         ContinentSouthAmerica,
+	"brazil":            ContinentSouthAmerica,
+	"chile":             ContinentSouthAmerica,
+	"colombia":          ContinentSouthAmerica,
+	"ecuador":           ContinentSouthAmerica,
+	"falkland islands":  ContinentSouthAmerica,
+	"french guiana":     ContinentSouthAmerica,
+	"galapagos islands": ContinentSouthAmerica,
+	"guyana":            ContinentSouthAmerica,
+	"peru":              ContinentSouthAmerica,
+	"suriname":          ContinentSouthAmerica,
+	"uruguay":           ContinentSouthAmerica,
+	"venezuela":         ContinentSouthAmerica,
+}
+
+// ContinentOf returns the continent that the given country belongs to according to
+// a built-in table of countries that have surf breaks. The lookup is case-insensitive.
+// False is returned when the country is not in the table.
+func ContinentOf(countryName string) (Continent, bool) {
+	c, ok := continents[strings.ToLower(strings.TrimSpace(countryName))]
+	return c, ok
+}
+
+// Continent returns the continent of the surf break's country. False is returned
+// when the continent is unknown.
+func (b Break) Continent() (Continent, bool) {
+	return ContinentOf(b.CountryName)
+}
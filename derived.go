@@ -0,0 +1,49 @@
+package surfforecast
+
+// DerivedValues holds the values that the package computes from an hourly forecast
+// rather than scrapes from www.surf-forecast.com. See HourlyForecast.Derived.
+type DerivedValues struct {
+	// AdjustedRating holds the rating score computed by DefaultRatingFunc, or by
+	// the function set with WithRatingFunc when computed by Scraper.Derived.
+	AdjustedRating float64
+
+	SurfHeightMin      Length
+	SurfHeightMax      Length
+	PrimarySwellHeight Length
+
+	// WindState holds the wind state parsed from Wind.State. It is
+	// WindStateUnknown when the state could not be parsed.
+	WindState WindState
+
+	// WindIsCalm reports whether the wind is calm. See Wind.IsCalm.
+	WindIsCalm bool
+}
+
+// Derived computes the derived values of the given hourly forecast. The values are
+// computed on every call rather than stored in HourlyForecast, so forecasts that
+// have been stored and loaded again pick up improvements of the formulas.
+func (h HourlyForecast) Derived() DerivedValues {
+	return h.derived(nil)
+}
+
+// Derived computes the derived values of the given hourly forecast like
+// HourlyForecast.Derived does, except that the adjusted rating is computed with the
+// function set with WithRatingFunc.
+func (s *Scraper) Derived(h HourlyForecast) DerivedValues {
+	return h.derived(s.ratingFunc)
+}
+
+// derived computes the derived values of the given hourly forecast, computing the
+// adjusted rating with the given function.
+func (h HourlyForecast) derived(fn RatingFunc) DerivedValues {
+	state, _ := ParseWindState(h.Wind.State)
+
+	return DerivedValues{
+		AdjustedRating:     h.AdjustedRating(fn),
+		SurfHeightMin:      h.SurfHeightMin(),
+		SurfHeightMax:      h.SurfHeightMax(),
+		PrimarySwellHeight: h.Swells.Primary.WaveHeight(),
+		WindState:          state,
+		WindIsCalm:         h.Wind.IsCalm(),
+	}
+}
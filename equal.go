@@ -0,0 +1,119 @@
+package surfforecast
+
+import "math"
+
+// floatTolerance is the maximum difference between two floats for them to be
+// considered equal by the Equal methods. It absorbs rounding errors introduced
+// when parsing and converting scraped values.
+const floatTolerance = 1e-6
+
+// Equal reports whether both forecasts hold the same values. Timestamps are
+// compared by the instants they represent regardless of their locations, and
+// floats are compared with a small tolerance.
+func (f *Forecast) Equal(other *Forecast) bool {
+	if f == nil || other == nil {
+		return f == other
+	}
+
+	if !f.IssuedAt.Equal(other.IssuedAt) || len(f.Daily) != len(other.Daily) {
+		return false
+	}
+
+	for i := range f.Daily {
+		if !f.Daily[i].Equal(other.Daily[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal reports whether both daily forecasts hold the same values. See
+// Forecast.Equal for details.
+func (d *DailyForecast) Equal(other *DailyForecast) bool {
+	if d == nil || other == nil {
+		return d == other
+	}
+
+	if !d.Timestamp.Equal(other.Timestamp) ||
+		d.Partial != other.Partial ||
+		d.Confidence != other.Confidence ||
+		len(d.Hourly) != len(other.Hourly) ||
+		len(d.Tides) != len(other.Tides) {
+		return false
+	}
+
+	for i := range d.Hourly {
+		if !d.Hourly[i].Equal(other.Hourly[i]) {
+			return false
+		}
+	}
+
+	for i := range d.Tides {
+		if !d.Tides[i].Equal(other.Tides[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal reports whether both hourly forecasts hold the same values. See
+// Forecast.Equal for details.
+func (h HourlyForecast) Equal(other HourlyForecast) bool {
+	return h.Timestamp.Equal(other.Timestamp) &&
+		h.Rating == other.Rating &&
+		h.RatingBand == other.RatingBand &&
+		h.Swells.Equal(other.Swells) &&
+		floatsEqual(h.SurfHeightMinInMeters, other.SurfHeightMinInMeters) &&
+		floatsEqual(h.SurfHeightMaxInMeters, other.SurfHeightMaxInMeters) &&
+		floatsEqual(h.WaveEnergyInKiloJoules, other.WaveEnergyInKiloJoules) &&
+		h.Wind.Equal(other.Wind)
+}
+
+// Equal reports whether both swells hold the same values. See Forecast.Equal for
+// details.
+func (s Swells) Equal(other Swells) bool {
+	if !s.Primary.Equal(other.Primary) || len(s.Secondary) != len(other.Secondary) {
+		return false
+	}
+
+	for i := range s.Secondary {
+		if !s.Secondary[i].Equal(other.Secondary[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal reports whether both swells hold the same values. See Forecast.Equal for
+// details.
+func (s Swell) Equal(other Swell) bool {
+	return floatsEqual(s.PeriodInSeconds, other.PeriodInSeconds) &&
+		floatsEqual(s.DirectionToInDegrees, other.DirectionToInDegrees) &&
+		s.DirectionFromInCompassPoints == other.DirectionFromInCompassPoints &&
+		floatsEqual(s.WaveHeightInMeters, other.WaveHeightInMeters) &&
+		s.Label == other.Label
+}
+
+// Equal reports whether both winds hold the same values. See Forecast.Equal for
+// details.
+func (w Wind) Equal(other Wind) bool {
+	return floatsEqual(w.SpeedInKilometersPerHour, other.SpeedInKilometersPerHour) &&
+		floatsEqual(w.DirectionToInDegrees, other.DirectionToInDegrees) &&
+		w.DirectionFromInCompassPoints == other.DirectionFromInCompassPoints &&
+		w.State == other.State
+}
+
+// Equal reports whether both tides hold the same values. See Forecast.Equal for
+// details.
+func (t Tide) Equal(other Tide) bool {
+	return t.Type == other.Type &&
+		t.Timestamp.Equal(other.Timestamp) &&
+		floatsEqual(t.HeightInMeters, other.HeightInMeters)
+}
+
+func floatsEqual(a, b float64) bool {
+	return math.Abs(a-b) <= floatTolerance
+}
@@ -0,0 +1,87 @@
+package surfforecast
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Stages of fetching and scraping that ScrapeError can point at.
+const (
+	// StageFetch is the stage of fetching a page from www.surf-forecast.com.
+	StageFetch = "fetch"
+	// StageParse is the stage of parsing a fetched page as HTML.
+	StageParse = "parse"
+	// StageSearchResults is the stage of parsing surf break search results.
+	StageSearchResults = "search-results"
+	// StageBreak is the stage of scraping a surf break.
+	StageBreak = "break"
+	// StageIssueDate is the stage of scraping the issue date of a forecast.
+	StageIssueDate = "issue-date"
+	// StageTable is the stage of finding the forecast table.
+	StageTable = "table"
+	// StageDays is the stage of scraping the days of a forecast.
+	StageDays = "days"
+	// StageHours is the stage of scraping the hours of a forecast.
+	StageHours = "hours"
+	// StageRatings is the stage of scraping the ratings of a forecast.
+	StageRatings = "ratings"
+	// StageSwells is the stage of scraping the swells of a forecast.
+	StageSwells = "swells"
+	// StageSurfHeights is the stage of scraping the surf heights of a forecast.
+	StageSurfHeights = "surf-heights"
+	// StageWaveEnergies is the stage of scraping the wave energies of a forecast.
+	StageWaveEnergies = "wave-energies"
+	// StageWinds is the stage of scraping the winds of a forecast.
+	StageWinds = "winds"
+	// StageWindStates is the stage of scraping the wind states of a forecast.
+	StageWindStates = "wind-states"
+	// StageTides is the stage of scraping the tides of a forecast.
+	StageTides = "tides"
+	// StageConfidences is the stage of scraping the confidences of a forecast.
+	StageConfidences = "confidences"
+	// StageRows is the stage of combining the scraped rows into a forecast.
+	StageRows = "rows"
+)
+
+// ScrapeError holds information about where and why fetching or scraping failed.
+// It wraps the underlying error, so errors.Is keeps matching errors such as
+// ErrBreakNotFound or ErrPremiumRequired.
+type ScrapeError struct {
+	// Endpoint holds the path of the page that was being fetched or scraped. It
+	// is empty when a page was scraped without being fetched, for instance by
+	// ScrapeForecast.
+	Endpoint string
+
+	// StatusCode holds the status code of the response. It is 0 when no response
+	// was received.
+	StatusCode int
+
+	// Stage holds the stage the failure happened at, for instance StageWinds.
+	Stage string
+
+	Err error
+}
+
+// Error implements error interface.
+func (e *ScrapeError) Error() string {
+	msg := fmt.Sprintf("%s: %v", e.Stage, e.Err)
+	if e.Endpoint != "" {
+		msg = e.Endpoint + ": " + msg
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (e *ScrapeError) Unwrap() error {
+	return e.Err
+}
+
+// withEndpoint sets the given endpoint to the given error in case if it is a
+// ScrapeError without an endpoint, and returns the error.
+func withEndpoint(err error, endpoint string) error {
+	var e *ScrapeError
+	if errors.As(err, &e) && e.Endpoint == "" {
+		e.Endpoint = endpoint
+	}
+	return err
+}
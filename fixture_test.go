@@ -0,0 +1,243 @@
+package surfforecast
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// loadFixture parses the HTML page with the given name from the testdata directory.
+// The fixtures are synthetic pages written by hand to the markup the scraper
+// assumes rather than pages captured from www.surf-forecast.com.
+func loadFixture(t *testing.T, name string) *html.Node {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("could not open fixture: %v", err)
+	}
+	defer f.Close()
+
+	n, err := html.Parse(f)
+	if err != nil {
+		t.Fatalf("could not parse fixture: %v", err)
+	}
+	return n
+}
+
+// mustLoadLocation loads the time location with the given name, or skips the test
+// when the IANA timezone database is not available.
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("could not load time location: %v", err)
+	}
+	return loc
+}
+
+func TestScraper_ScrapeForecast_Fixture(t *testing.T) {
+	makassar := mustLoadLocation(t, "Asia/Makassar")
+
+	f, err := New().ScrapeForecast(loadFixture(t, "forecast.html"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := time.Date(2021, time.October, 31, 16, 0, 0, 0, makassar); !f.IssuedAt.Equal(want) {
+		t.Errorf("issued at: got %v, want %v", f.IssuedAt, want)
+	}
+	if f.Location.String() != "Asia/Makassar" {
+		t.Errorf("location: got %v, want Asia/Makassar", f.Location)
+	}
+
+	if len(f.Daily) != 2 {
+		t.Fatalf("got %d days, want 2", len(f.Daily))
+	}
+
+	days := []struct {
+		date       time.Time
+		partial    bool
+		confidence Confidence
+		hours      []int
+		ratings    []int
+		bands      []RatingBand
+		surfMin    []float64
+		surfMax    []float64
+		swells     []int
+		energies   []float64
+		windSpeeds []float64
+		windTo     []float64
+		windFrom   []string
+		windStates []string
+		tides      []Tide
+	}{
+		{
+			date:       time.Date(2021, time.October, 31, 0, 0, 0, 0, makassar),
+			partial:    true,
+			confidence: ConfidenceUnknown,
+			hours:      []int{18, 21},
+			ratings:    []int{1, 4},
+			bands:      []RatingBand{RatingBandPoor, RatingBandFair},
+			surfMin:    []float64{1.2, 1.5},
+			surfMax:    []float64{1.8, 1.5},
+			swells:     []int{1, 1},
+			energies:   []float64{420, 450},
+			windSpeeds: []float64{15, 10},
+			windTo:     []float64{225, 270},
+			windFrom:   []string{"NE", "E"},
+			windStates: []string{"offshore", "cross shore"},
+			tides: []Tide{
+				{Type: TideHigh, Timestamp: time.Date(2021, time.October, 31, 18, 12, 0, 0, makassar), HeightInMeters: 2.1},
+			},
+		},
+		{
+			date:       time.Date(2021, time.November, 1, 0, 0, 0, 0, makassar),
+			confidence: ConfidenceMedium,
+			hours:      []int{0, 9, 15},
+			ratings:    []int{3, 7, 5},
+			bands:      []RatingBand{RatingBandUnknown, RatingBandGood, RatingBandFair},
+			surfMin:    []float64{1.5, 2, 6 * metersPerFoot},
+			surfMax:    []float64{2, 2, 6 * metersPerFoot},
+			swells:     []int{2, 1, 1},
+			energies:   []float64{600, 720, 680},
+			windSpeeds: []float64{0, 5, 20},
+			windTo:     []float64{0, 0, 45},
+			windFrom:   []string{"", "S", "SW"},
+			windStates: []string{"glassy", "offshore", "on shore"},
+			tides: []Tide{
+				{Type: TideLow, Timestamp: time.Date(2021, time.November, 1, 0, 25, 0, 0, makassar), HeightInMeters: 0.4},
+				{Type: TideHigh, Timestamp: time.Date(2021, time.November, 1, 6, 40, 0, 0, makassar), HeightInMeters: 2.3},
+				{Type: TideLow, Timestamp: time.Date(2021, time.November, 1, 12, 58, 0, 0, makassar), HeightInMeters: 0.3},
+			},
+		},
+	}
+
+	for i, want := range days {
+		d := f.Daily[i]
+
+		if !d.Timestamp.Equal(want.date) {
+			t.Errorf("day %d: got date %v, want %v", i, d.Timestamp, want.date)
+		}
+		if d.Partial != want.partial {
+			t.Errorf("day %d: got partial %v, want %v", i, d.Partial, want.partial)
+		}
+		if d.Confidence != want.confidence {
+			t.Errorf("day %d: got confidence %v, want %v", i, d.Confidence, want.confidence)
+		}
+
+		if len(d.Hourly) != len(want.hours) {
+			t.Fatalf("day %d: got %d hours, want %d", i, len(d.Hourly), len(want.hours))
+		}
+		for j, h := range d.Hourly {
+			if h.Timestamp.Hour() != want.hours[j] {
+				t.Errorf("day %d hour %d: got hour %d, want %d", i, j, h.Timestamp.Hour(), want.hours[j])
+			}
+			if h.Rating != want.ratings[j] || h.RatingBand != want.bands[j] {
+				t.Errorf("day %d hour %d: got rating %d %v, want %d %v",
+					i, j, h.Rating, h.RatingBand, want.ratings[j], want.bands[j])
+			}
+			if !floatEqual(h.SurfHeightMinInMeters, want.surfMin[j]) || !floatEqual(h.SurfHeightMaxInMeters, want.surfMax[j]) {
+				t.Errorf("day %d hour %d: got surf height %v-%v, want %v-%v",
+					i, j, h.SurfHeightMinInMeters, h.SurfHeightMaxInMeters, want.surfMin[j], want.surfMax[j])
+			}
+			if n := 1 + len(h.Swells.Secondary); n != want.swells[j] {
+				t.Errorf("day %d hour %d: got %d swells, want %d", i, j, n, want.swells[j])
+			}
+			if h.Swells.Primary.Label == "" || h.Swells.Primary.DirectionFromInCompassPoints != "SW" {
+				t.Errorf("day %d hour %d: got primary swell %+v", i, j, h.Swells.Primary)
+			}
+			if h.WaveEnergyInKiloJoules != want.energies[j] {
+				t.Errorf("day %d hour %d: got wave energy %v, want %v", i, j, h.WaveEnergyInKiloJoules, want.energies[j])
+			}
+			if h.Wind.SpeedInKilometersPerHour != want.windSpeeds[j] ||
+				h.Wind.DirectionToInDegrees != want.windTo[j] ||
+				h.Wind.DirectionFromInCompassPoints != want.windFrom[j] ||
+				h.Wind.State != want.windStates[j] {
+				t.Errorf("day %d hour %d: got wind %+v", i, j, h.Wind)
+			}
+		}
+
+		if len(d.Tides) != len(want.tides) {
+			t.Fatalf("day %d: got %d tides, want %d", i, len(d.Tides), len(want.tides))
+		}
+		for j, tide := range d.Tides {
+			w := want.tides[j]
+			if tide.Type != w.Type || !tide.Timestamp.Equal(w.Timestamp) || tide.HeightInMeters != w.HeightInMeters {
+				t.Errorf("day %d tide %d: got %+v, want %+v", i, j, tide, w)
+			}
+		}
+	}
+
+	if err := f.Validate(); err != nil {
+		t.Errorf("invalid forecast: %v", err)
+	}
+}
+
+func TestScraper_ScrapeForecast_LockedFixture(t *testing.T) {
+	mustLoadLocation(t, "Asia/Makassar")
+
+	s := New()
+	n := loadFixture(t, "forecast_locked.html")
+
+	if _, err := s.ScrapeForecast(n); !errors.Is(err, ErrPremiumRequired) {
+		t.Errorf("got %v, want %v", err, ErrPremiumRequired)
+	}
+
+	result, err := s.scrapeForecastPartially(n)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Errors) != 1 {
+		t.Fatalf("got errors %v, want 1", result.Errors)
+	}
+
+	var scrapeErr *ScrapeError
+	if !errors.As(result.Errors[0], &scrapeErr) || scrapeErr.Stage != StageRatings {
+		t.Errorf("got %v, want stage %q", result.Errors[0], StageRatings)
+	}
+
+	hours := result.Forecast.Daily[0].Hourly
+	if len(hours) != 2 || hours[0].Rating != 0 || hours[0].WaveEnergyInKiloJoules != 420 {
+		t.Errorf("got hours %+v", hours)
+	}
+}
+
+func TestScraper_ScrapeBreak_Fixture(t *testing.T) {
+	brk, err := New(WithBaseURL("https://surf.example.com")).ScrapeBreak(loadFixture(t, "break.html"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := Break{
+		Name:               "Uluwatu",
+		CountryName:        "Indonesia",
+		PhotoURL:           "https://surf.example.com/system/images/uluwatu.jpg",
+		MapImageURL:        "https://maps.example.com/uluwatu.png",
+		Type:               "Reef",
+		BestSwellDirection: "SW",
+		BestWindDirection:  "E",
+		IdealSwellSize:     "1.5-2.5m",
+	}
+	if brk.Name != want.Name ||
+		brk.CountryName != want.CountryName ||
+		brk.PhotoURL != want.PhotoURL ||
+		brk.MapImageURL != want.MapImageURL ||
+		brk.Type != want.Type ||
+		brk.BestSwellDirection != want.BestSwellDirection ||
+		brk.BestWindDirection != want.BestWindDirection ||
+		brk.BestTide != want.BestTide ||
+		brk.IdealSwellSize != want.IdealSwellSize {
+		t.Errorf("got %+v, want %+v", brk, want)
+	}
+}
+
+func floatEqual(a, b float64) bool {
+	const epsilon = 1e-9
+	return a-b < epsilon && b-a < epsilon
+}
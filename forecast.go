@@ -1,36 +1,40 @@
 package surfforecast
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/tkuchiki/go-timezone"
 	"github.com/ztimes2/surfforecast-go/internal/htmlutil"
 	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
 const (
 	pathFormatForecastsForEightDays = "/breaks/%s/forecasts/latest"
+	pathFormatForecastsForSixDays   = "/breaks/%s/forecasts/latest/six_day"
 )
 
 const (
-	classBreakHeaderIssued   = "break-header__issued"
-	classForecastTableBasic  = "forecast-table__basic"
-	classForecastTableRow    = "forecast-table__row"
-	classForecastTableCell   = "forecast-table__cell"
-	classForecastTableValue  = "forecast-table__value"
-	classForecastTableTime   = "forecast-table-time"
-	classForecastTableDays   = "forecast-table-days"
-	classForecastTableRating = "forecast-table-rating"
-	classIsDayEnd            = "is-day-end"
-	classWindIcon            = "wind-icon"
-	classWindLetters         = "wind-icon__letters"
-	classWindIconArrow       = "wind-icon__arrow"
+	classBreakHeaderIssued     = "break-header__issued"
+	classForecastTableBasic    = "forecast-table__basic"
+	classForecastTableDetailed = "forecast-table__detailed"
+	classForecastTableRow      = "forecast-table__row"
+	classForecastTableCell     = "forecast-table__cell"
+	classForecastTableValue    = "forecast-table__value"
+	classIsDayEnd              = "is-day-end"
+	classIsLocked              = "is-locked"
+	classWindIcon              = "wind-icon"
+	classWindLetters           = "wind-icon__letters"
+	classWindIconArrow         = "wind-icon__arrow"
+	classSwellIconArrow        = "swell-icon__arrow"
 
 	attributeDataRowName    = "data-row-name"
 	attributeDataSwellState = "data-swell-state"
@@ -46,6 +50,21 @@ const (
 
 	transformRotatePrefix = "rotate("
 	transformRotateSuffix = ")"
+
+	relativeIssueSuffix = "ago"
+
+	waveEnergyUnit = "kJ"
+)
+
+var (
+	// ErrPremiumRequired indicates that a forecast includes rows that are locked
+	// behind www.surf-forecast.com's premium subscription and render placeholders
+	// instead of actual values.
+	ErrPremiumRequired = errors.New("premium required")
+
+	// ErrIssueDateOutOfRange indicates that a forecast was issued further from the
+	// current time than allowed by WithIssueDateValidation.
+	ErrIssueDateOutOfRange = errors.New("issue date out of range")
 )
 
 // EightDaysForecast returns the given surf break's latest forecast for 8 subsequent
@@ -53,42 +72,214 @@ const (
 // surf break's local timezone. A forecast might contain 9 days when the function
 // is called during the transition between days.
 //
-// ErrBreakNotFound is returned when the given surf break does not exist.
+// ErrBreakNotFound is returned when the given surf break does not exist, and
+// ErrBreakHasNoForecast is returned when it redirects to a region page.
 func (s *Scraper) EightDaysForecast(breakName string) (*Forecast, error) {
 	// IDEA: use chromedp to dynamically expand daily forecasts in order to scrape
 	// more information.
 
+	return s.forecast(context.Background(), fmt.Sprintf(pathFormatForecastsForEightDays, breakName))
+}
+
+// SixDaysForecast returns the given surf break's latest forecast for 6 subsequent
+// days specified by its name. It works like EightDaysForecast but scrapes the
+// page of the site's six-day view instead.
+//
+// ErrBreakNotFound is returned when the given surf break does not exist, and
+// ErrBreakHasNoForecast is returned when it redirects to a region page.
+func (s *Scraper) SixDaysForecast(breakName string) (*Forecast, error) {
+	return s.forecast(context.Background(), fmt.Sprintf(pathFormatForecastsForSixDays, breakName))
+}
+
+// forecast fetches the forecast page at the given path and scrapes it.
+func (s *Scraper) forecast(ctx context.Context, path string) (*Forecast, error) {
+	node, header, err := s.fetchPage(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	forecasts, err := s.ScrapeForecast(node)
+	if err != nil {
+		return nil, withEndpoint(err, path)
+	}
+
+	forecasts.ResponseHeaders = s.responseHeaders(header)
+
+	return forecasts, nil
+}
+
+// FetchForecastHTML returns the raw HTML page of the given surf break's latest
+// forecast for 8 subsequent days without scraping it. It is meant for debugging
+// scraping failures and capturing pages as regression fixtures.
+//
+// ErrBreakNotFound is returned when the given surf break does not exist, and
+// ErrBreakHasNoForecast is returned when it redirects to a region page.
+func (s *Scraper) FetchForecastHTML(ctx context.Context, breakName string) ([]byte, error) {
+	body, _, err := s.fetch(ctx, fmt.Sprintf(pathFormatForecastsForEightDays, breakName))
+	return body, err
+}
+
+// EightDaysForecastPartial works like EightDaysForecast, but it does not fail when
+// some of the forecast's sections (ratings, swells, surf heights, wave energies,
+// winds, wind states or tides) could not be scraped. Instead, the fields of such sections are left
+// empty, and the failures are reported in ScrapeResult.Errors. Sections that are
+// locked behind the premium subscription are reported with ErrPremiumRequired.
+// Sections whose number of days or hours does not match the forecast's days and
+// hours are left empty and reported in the same way.
+//
+// An error is still returned when the forecast could not be scraped at all, for
+// instance when its days and hours could not be scraped.
+//
+// ErrBreakNotFound is returned when the given surf break does not exist, and
+// ErrBreakHasNoForecast is returned when it redirects to a region page.
+func (s *Scraper) EightDaysForecastPartial(breakName string) (*ScrapeResult, error) {
 	path := fmt.Sprintf(pathFormatForecastsForEightDays, breakName)
 
-	req, err := http.NewRequest(http.MethodGet, s.baseURL+path, nil)
+	node, header, err := s.fetchPage(context.Background(), path)
 	if err != nil {
-		return nil, fmt.Errorf("could not prepare request: %w", err)
+		return nil, err
 	}
 
-	resp, err := s.httpClient.Do(req)
+	result, err := s.scrapeForecastPartially(node)
 	if err != nil {
-		return nil, fmt.Errorf("could not send request: %w", err)
+		return nil, withEndpoint(err, path)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		if resp.StatusCode == http.StatusNotFound {
-			return nil, ErrBreakNotFound
-		}
-		return nil, fmt.Errorf("received response with %d status code", resp.StatusCode)
+	result.Forecast.ResponseHeaders = s.responseHeaders(header)
+
+	for i, err := range result.Errors {
+		result.Errors[i] = withEndpoint(err, path)
 	}
 
-	defer resp.Body.Close()
-	node, err := html.Parse(resp.Body)
+	return result, nil
+}
+
+// ForecastDayCount returns the number of days that the given surf break's latest
+// forecast covers. It is cheaper than EightDaysForecast since only the days of the
+// forecast get scraped. The count does not exceed the limit set by WithMaxDays.
+//
+// ErrBreakNotFound is returned when the given surf break does not exist, and
+// ErrBreakHasNoForecast is returned when it redirects to a region page.
+func (s *Scraper) ForecastDayCount(breakName string) (int, error) {
+	path := fmt.Sprintf(pathFormatForecastsForEightDays, breakName)
+
+	node, _, err := s.fetchPage(context.Background(), path)
 	if err != nil {
-		return nil, fmt.Errorf("could not parse response body as html: %w", err)
+		return 0, err
 	}
 
-	forecasts, err := scrapeForecast(node, s.timezones)
+	tableNode, err := s.findForecastTable(node)
 	if err != nil {
-		return nil, fmt.Errorf("could not scrape html: %w", err)
+		return 0, &ScrapeError{Endpoint: path, Stage: StageTable, Err: err}
 	}
 
-	return forecasts, nil
+	days, err := scrapeDays(indexForecastTable(tableNode))
+	if err != nil {
+		return 0, &ScrapeError{Endpoint: path, Stage: StageDays, Err: err}
+	}
+	if s.maxDays > 0 && len(days) > s.maxDays {
+		return s.maxDays, nil
+	}
+
+	return len(days), nil
+}
+
+// AvailableRows returns the names of the rows that the given surf break's latest
+// forecast table consists of, in the order they are displayed in, for instance
+// "days", "time" and "rating". The set of rows differs between surf breaks, so it
+// can be used for detecting which information a forecast is going to include.
+//
+// ErrBreakNotFound is returned when the given surf break does not exist, and
+// ErrBreakHasNoForecast is returned when it redirects to a region page.
+func (s *Scraper) AvailableRows(breakName string) ([]string, error) {
+	path := fmt.Sprintf(pathFormatForecastsForEightDays, breakName)
+
+	node, _, err := s.fetchPage(context.Background(), path)
+	if err != nil {
+		return nil, err
+	}
+
+	tableNode, err := s.findForecastTable(node)
+	if err != nil {
+		return nil, &ScrapeError{Endpoint: path, Stage: StageTable, Err: err}
+	}
+
+	return forecastTableRowNames(tableNode), nil
+}
+
+// WindForecast returns the winds of the given surf break's latest forecast broken
+// down into days and hours. It is cheaper than EightDaysForecast since only the
+// wind and wind state rows of the forecast get scraped, and only as many days as
+// set by WithMaxDays.
+//
+// ErrBreakNotFound is returned when the given surf break does not exist, and
+// ErrBreakHasNoForecast is returned when it redirects to a region page.
+func (s *Scraper) WindForecast(breakName string) ([][]Wind, error) {
+	path := fmt.Sprintf(pathFormatForecastsForEightDays, breakName)
+
+	node, _, err := s.fetchPage(context.Background(), path)
+	if err != nil {
+		return nil, err
+	}
+
+	tableNode, err := s.findForecastTable(node)
+	if err != nil {
+		return nil, &ScrapeError{Endpoint: path, Stage: StageTable, Err: err}
+	}
+
+	table := indexForecastTable(tableNode)
+	table.maxDays = s.maxDays
+
+	winds, err := s.scrapeWinds(table)
+	if err != nil {
+		return nil, &ScrapeError{Endpoint: path, Stage: StageWinds, Err: err}
+	}
+
+	states, err := scrapeWindStates(table)
+	if err != nil {
+		return nil, &ScrapeError{Endpoint: path, Stage: StageWindStates, Err: err}
+	}
+
+	if len(winds) != len(states) {
+		return nil, &ScrapeError{
+			Endpoint: path,
+			Stage:    StageRows,
+			Err:      errors.New("winds and wind states must have equal number of days"),
+		}
+	}
+
+	allWinds := make([][]Wind, len(winds))
+	for i := range winds {
+		if len(winds[i]) != len(states[i]) {
+			return nil, &ScrapeError{
+				Endpoint: path,
+				Stage:    StageRows,
+				Err:      errors.New("winds and wind states must have equal number of hours"),
+			}
+		}
+
+		allWinds[i] = make([]Wind, len(winds[i]))
+		for j, w := range winds[i] {
+			allWinds[i][j] = Wind{
+				SpeedInKilometersPerHour:     w.speed,
+				DirectionToInDegrees:         w.degrees,
+				DirectionFromInCompassPoints: w.letters,
+				State:                        states[i][j],
+			}
+		}
+	}
+
+	return allWinds, nil
+}
+
+// ScrapeResult holds a forecast that might be missing some of its sections along
+// with the failures that caused them to be missing.
+type ScrapeResult struct {
+	Forecast *Forecast
+
+	// Errors holds a failure per each section of the forecast that could not be
+	// scraped. It is empty when the forecast has been scraped completely.
+	Errors []error
 }
 
 // Forecast holds a forecast for multiple days.
@@ -97,40 +288,305 @@ type Forecast struct {
 	// using the surf break's local timezone.
 	IssuedAt time.Time
 	Daily    []*DailyForecast
+
+	// Location holds the surf break's time location that the forecast's timestamps
+	// use. It is taken from the timezone that the page declares in its markup when
+	// present, and it is resolved from the issue text's timezone abbreviation
+	// otherwise, unless Scraper is configured using WithForecastLocation. It is nil
+	// for forecasts assembled by MergeDaily, and it is not compared by Equal.
+	Location *time.Location
+
+	// ResponseHeaders holds the headers of the response the forecast was scraped
+	// from. It is nil unless Scraper is configured using WithExposeResponseHeaders,
+	// and it is not compared by Equal.
+	ResponseHeaders http.Header
 }
 
-// newForecast combines the scraped forecast data into Forecast.
-func newForecast(
-	issuedAt time.Time,
-	days []int,
-	hours [][]int,
-	ratings [][]int,
-	swells [][]Swells,
-	waveEnergies [][]float64,
-	winds [][]wind,
-	windStates [][]string) (*Forecast, error) {
+// MergeDaily assembles a forecast from the given daily forecasts that have been
+// obtained separately, for instance from different pages or cached snapshots. The
+// days must be given in chronological order without repeating each other. Since
+// the days might have been issued at different moments, IssuedAt of the returned
+// forecast is left zero.
+func MergeDaily(days ...*DailyForecast) (*Forecast, error) {
+	for i, d := range days {
+		if d == nil {
+			return nil, fmt.Errorf("day %d is nil", i)
+		}
+
+		if i > 0 && !d.Timestamp.After(days[i-1].Timestamp) {
+			return nil, fmt.Errorf(
+				"day %s does not come after day %s",
+				d.Timestamp.Format("2006-01-02"), days[i-1].Timestamp.Format("2006-01-02"),
+			)
+		}
+	}
+
+	return &Forecast{
+		Daily: append([]*DailyForecast{}, days...),
+	}, nil
+}
+
+// IsStale checks if the given forecast was issued longer than the given maximum
+// age before the given current time.
+func (f *Forecast) IsStale(maxAge time.Duration, now time.Time) bool {
+	return now.Sub(f.IssuedAt) > maxAge
+}
+
+// DateRange returns the timestamps of the first and the last days of the forecast,
+// for instance for displaying the period the forecast covers. The timestamps are
+// the ones of DailyForecast, which account for month and year rollovers. Zero
+// timestamps are returned when the forecast has no days.
+func (f *Forecast) DateRange() (start, end time.Time) {
+	if len(f.Daily) == 0 {
+		return time.Time{}, time.Time{}
+	}
+	return f.Daily[0].Timestamp, f.Daily[len(f.Daily)-1].Timestamp
+}
+
+// Flatten returns the hourly forecasts of all days as a single slice sorted by
+// their timestamps in ascending order.
+func (f *Forecast) Flatten() []HourlyForecast {
+	var forecasts []HourlyForecast
+	for _, d := range f.Daily {
+		if d != nil {
+			forecasts = append(forecasts, d.Hourly...)
+		}
+	}
+
+	sort.SliceStable(forecasts, func(i, j int) bool {
+		return forecasts[i].Timestamp.Before(forecasts[j].Timestamp)
+	})
+
+	return forecasts
+}
+
+// ByTimestamp returns the hourly forecasts of all days keyed by their timestamps.
+// The keys use the location of the forecast's timestamps, which is the surf break's
+// local timezone unless overridden, and since time.Time keys are compared including
+// their locations, lookup keys must be constructed in the same location, for
+// instance using time.Date with IssuedAt.Location(). Days that are nil are
+// skipped.
+func (f *Forecast) ByTimestamp() map[time.Time]HourlyForecast {
+	forecasts := make(map[time.Time]HourlyForecast)
+	for _, h := range f.Flatten() {
+		forecasts[h.Timestamp] = h
+	}
+	return forecasts
+}
 
-	if len(days) != len(hours) {
-		return nil, errors.New("days and hours must have equal number of elements")
+// Between returns the hourly forecasts of all days whose timestamps fall within
+// the given time window including its boundaries, in chronological order. The
+// timestamps are compared by the instants they represent, so the window may use
+// any timezone. Nothing is returned when the window is inverted. Days that are
+// nil are skipped.
+func (f *Forecast) Between(start, end time.Time) []HourlyForecast {
+	var forecasts []HourlyForecast
+	for _, h := range f.Flatten() {
+		if !h.Timestamp.Before(start) && !h.Timestamp.After(end) {
+			forecasts = append(forecasts, h)
+		}
+	}
+	return forecasts
+}
+
+// forecastRows holds the rows of a forecast table broken down into days. A row
+// is nil when it could not be scraped.
+type forecastRows struct {
+	days         []int
+	hours        [][]clockTime
+	ratings      [][]rating
+	swells       [][]Swells
+	surfHeights  [][]surfHeight
+	waveEnergies [][]waveEnergy
+	winds        [][]wind
+	windStates   [][]string
+	tides        [][]tide
+	confidences  []Confidence
+
+	// nextHours holds the hours of the day that follows the last scraped day when
+	// the number of scraped days is limited. It is nil otherwise.
+	nextHours []clockTime
+
+	// withRawValues indicates that the raw scraped texts must be attached to the
+	// hourly forecasts.
+	withRawValues bool
+}
+
+func (r forecastRows) validate() error {
+	if len(r.days) != len(r.hours) {
+		return errors.New("days and hours must have equal number of elements")
+	}
+	if r.ratings != nil && len(r.days) != len(r.ratings) {
+		return errors.New("days and ratings must have equal number of elements")
+	}
+	if r.swells != nil && len(r.days) != len(r.swells) {
+		return errors.New("days and swells must have equal number of elements")
 	}
-	if len(days) != len(ratings) {
-		return nil, errors.New("days and ratings must have equal number of elements")
+	if r.surfHeights != nil && len(r.days) != len(r.surfHeights) {
+		return errors.New("days and surf heights must have equal number of elements")
 	}
-	if len(days) != len(swells) {
-		return nil, errors.New("days and swells must have equal number of elements")
+	if r.waveEnergies != nil && len(r.days) != len(r.waveEnergies) {
+		return errors.New("days and wave energies must have equal number of elements")
 	}
-	if len(days) != len(waveEnergies) {
-		return nil, errors.New("days and wave energies must have equal number of elements")
+	if r.winds != nil && len(r.days) != len(r.winds) {
+		return errors.New("days and winds must have equal number of elements")
 	}
-	if len(days) != len(winds) {
-		return nil, errors.New("days and winds must have equal number of elements")
+	if r.windStates != nil && len(r.days) != len(r.windStates) {
+		return errors.New("days and wind states must have equal number of elements")
 	}
-	if len(days) != len(windStates) {
-		return nil, errors.New("days and wind states must have equal number of elements")
+	if r.tides != nil && len(r.days) != len(r.tides) {
+		return errors.New("days and tides must have equal number of elements")
+	}
+	if r.confidences != nil && len(r.days) != len(r.confidences) {
+		return errors.New("days and confidences must have equal number of elements")
+	}
+	return nil
+}
+
+// dropMismatchedRows drops the optional rows whose number of days, or number of
+// hours of any day, does not match the ones of the hours row, so that a single
+// malformed row does not fail the whole forecast. A ScrapeError is returned for
+// each dropped row.
+func (r *forecastRows) dropMismatchedRows() []error {
+	var errs []error
+
+	matches := func(stage, name string, days int, hoursOf func(int) int) bool {
+		err := r.checkRowLength(name, days, hoursOf)
+		if err != nil {
+			errs = append(errs, &ScrapeError{Stage: stage, Err: err})
+		}
+		return err == nil
+	}
+
+	if r.ratings != nil && !matches(StageRatings, "ratings", len(r.ratings),
+		func(i int) int { return len(r.ratings[i]) }) {
+		r.ratings = nil
+	}
+	if r.swells != nil && !matches(StageSwells, "swells", len(r.swells),
+		func(i int) int { return len(r.swells[i]) }) {
+		r.swells = nil
+	}
+	if r.surfHeights != nil && !matches(StageSurfHeights, "surf heights", len(r.surfHeights),
+		func(i int) int { return len(r.surfHeights[i]) }) {
+		r.surfHeights = nil
+	}
+	if r.waveEnergies != nil && !matches(StageWaveEnergies, "wave energies", len(r.waveEnergies),
+		func(i int) int { return len(r.waveEnergies[i]) }) {
+		r.waveEnergies = nil
+	}
+	if r.winds != nil && !matches(StageWinds, "winds", len(r.winds),
+		func(i int) int { return len(r.winds[i]) }) {
+		r.winds = nil
+	}
+	if r.windStates != nil && !matches(StageWindStates, "wind states", len(r.windStates),
+		func(i int) int { return len(r.windStates[i]) }) {
+		r.windStates = nil
+	}
+	if r.tides != nil && !matches(StageTides, "tides", len(r.tides), nil) {
+		r.tides = nil
+	}
+	if r.confidences != nil && !matches(StageConfidences, "confidences", len(r.confidences), nil) {
+		r.confidences = nil
+	}
+
+	return errs
+}
+
+// checkRowLength checks that a row with the given name has as many days as the
+// days row and, unless hoursOf is nil, as many hours in each day as the hours row.
+func (r forecastRows) checkRowLength(name string, days int, hoursOf func(int) int) error {
+	if days != len(r.days) {
+		return fmt.Errorf("days and %s must have equal number of elements", name)
+	}
+	if hoursOf == nil {
+		return nil
+	}
+	for i := range r.hours {
+		if hoursOf(i) != len(r.hours[i]) {
+			return fmt.Errorf("hours and %s of day %d must have equal number of elements", name, r.days[i])
+		}
+	}
+	return nil
+}
+
+// daily returns the rows of the day with the given index.
+func (r forecastRows) daily(i int) dailyRows {
+	d := dailyRows{
+		hours:         r.hours[i],
+		withRawValues: r.withRawValues,
+	}
+	if r.ratings != nil {
+		d.ratings = r.ratings[i]
+	}
+	if r.swells != nil {
+		d.swells = r.swells[i]
+	}
+	if r.surfHeights != nil {
+		d.surfHeights = r.surfHeights[i]
+	}
+	if r.waveEnergies != nil {
+		d.waveEnergies = r.waveEnergies[i]
+	}
+	if r.winds != nil {
+		d.winds = r.winds[i]
+	}
+	if r.windStates != nil {
+		d.windStates = r.windStates[i]
+	}
+	if r.tides != nil {
+		d.tides = r.tides[i]
+	}
+	if r.confidences != nil {
+		d.confidence = r.confidences[i]
+	}
+	return d
+}
+
+// dailyRows holds the rows of a forecast table that belong to a single day. A
+// row is nil when it could not be scraped.
+type dailyRows struct {
+	hours         []clockTime
+	ratings       []rating
+	swells        []Swells
+	surfHeights   []surfHeight
+	waveEnergies  []waveEnergy
+	winds         []wind
+	windStates    []string
+	tides         []tide
+	confidence    Confidence
+	withRawValues bool
+}
+
+func (r dailyRows) validate() error {
+	if r.ratings != nil && len(r.hours) != len(r.ratings) {
+		return errors.New("hours and ratings must have equal number of elements")
+	}
+	if r.swells != nil && len(r.hours) != len(r.swells) {
+		return errors.New("hours and swells must have equal number of elements")
+	}
+	if r.surfHeights != nil && len(r.hours) != len(r.surfHeights) {
+		return errors.New("hours and surf heights must have equal number of elements")
+	}
+	if r.waveEnergies != nil && len(r.hours) != len(r.waveEnergies) {
+		return errors.New("hours and wave energies must have equal number of elements")
+	}
+	if r.winds != nil && len(r.hours) != len(r.winds) {
+		return errors.New("hours and winds must have equal number of elements")
+	}
+	if r.windStates != nil && len(r.hours) != len(r.windStates) {
+		return errors.New("hours and wind states must have equal number of elements")
+	}
+	return nil
+}
+
+// newForecast combines the scraped forecast data into Forecast.
+func newForecast(issuedAt time.Time, rows forecastRows) (*Forecast, error) {
+	if err := rows.validate(); err != nil {
+		return nil, err
 	}
 
 	var (
-		forecasts = make([]*DailyForecast, len(days))
+		forecasts = make([]*DailyForecast, len(rows.days))
 		year      = issuedAt.Year()
 		month     = issuedAt.Month()
 
@@ -138,31 +594,23 @@ func newForecast(
 	)
 	for i := range forecasts {
 		if previous != nil {
-			// Handle the case when a forecast contains days of two subsequent months.
-			if previous.Timestamp.Day() > days[i] {
-				if month+1 > time.December {
+			// Handle the case when a forecast contains days of two subsequent months
+			// or years.
+			if previous.Timestamp.Day() > rows.days[i] {
+				month++
+				if month > time.December {
 					month = time.January
+					year++
 				}
-				month++
-			}
-
-			// Handle the case when a forecast contains days of two subsequent years.
-			if previous.Timestamp.Month() > month {
-				year++
 			}
 		}
 
 		f, err := newDailyForecast(
 			issuedAt.Location(),
-			issuedAt.Year(),
+			year,
 			month,
-			days[i],
-			hours[i],
-			ratings[i],
-			swells[i],
-			waveEnergies[i],
-			winds[i],
-			windStates[i],
+			rows.days[i],
+			rows.daily(i),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("could not create forecast: %w", err)
@@ -172,6 +620,17 @@ func newForecast(
 		previous = f
 	}
 
+	// The site omits the hours of the current day that have already passed, so
+	// the first day might start later than the rest of the days. Since the second
+	// day is always complete, its first hour is used as a reference of the site's
+	// usual first slot, even when the second day itself has not been scraped.
+	switch {
+	case len(forecasts) > 1:
+		forecasts[0].Partial = startsLaterThan(forecasts[0], forecasts[1])
+	case len(forecasts) == 1 && len(rows.nextHours) > 0 && len(forecasts[0].Hourly) > 0:
+		forecasts[0].Partial = forecasts[0].Hourly[0].Timestamp.Hour() > rows.nextHours[0].hour
+	}
+
 	return &Forecast{
 		IssuedAt: issuedAt,
 		Daily:    forecasts,
@@ -184,6 +643,41 @@ type DailyForecast struct {
 	// using the surf break's local timezone.
 	Timestamp time.Time
 	Hourly    []HourlyForecast
+
+	// Tides holds the tide extremes of the day in chronological order. It is
+	// empty when the forecast does not include tides.
+	Tides []Tide
+
+	// Partial indicates that the day has already been underway at the moment of
+	// scraping, so its hourly forecasts do not start at the site's usual first
+	// slot. Only the first day of a forecast can be partial.
+	Partial bool
+
+	// Confidence holds the site's confidence in the forecast of the day. It is
+	// ConfidenceUnknown when the site does not display it, which is usually the
+	// case for the near-term days.
+	Confidence Confidence
+}
+
+// hoursIncrease checks if the hourly forecasts of the given daily forecast are in
+// strictly increasing order. Hours that jump back signal that the cells of the
+// forecast table have been associated with wrong columns.
+func hoursIncrease(d *DailyForecast) bool {
+	for i := 1; i < len(d.Hourly); i++ {
+		if !d.Hourly[i].Timestamp.After(d.Hourly[i-1].Timestamp) {
+			return false
+		}
+	}
+	return true
+}
+
+// startsLaterThan checks if the first hour of the given daily forecast is later
+// than the first hour of the reference daily forecast.
+func startsLaterThan(f, reference *DailyForecast) bool {
+	if len(f.Hourly) == 0 || len(reference.Hourly) == 0 {
+		return false
+	}
+	return f.Hourly[0].Timestamp.Hour() > reference.Hourly[0].Timestamp.Hour()
 }
 
 // newDailyForecast combines the scraped forecast data of a single day into DailyForecast.
@@ -192,60 +686,122 @@ func newDailyForecast(
 	year int,
 	month time.Month,
 	day int,
-	hours []int,
-	ratings []int,
-	swells []Swells,
-	waveEnergies []float64,
-	winds []wind,
-	windStates []string) (*DailyForecast, error) {
+	rows dailyRows) (*DailyForecast, error) {
 
-	if len(hours) != len(ratings) {
-		return nil, errors.New("hours and ratings must have equal number of elements")
-	}
-	if len(hours) != len(swells) {
-		return nil, errors.New("hours and swells must have equal number of elements")
-	}
-	if len(hours) != len(waveEnergies) {
-		return nil, errors.New("hours and wave energies must have equal number of elements")
-	}
-	if len(hours) != len(winds) {
-		return nil, errors.New("hours and winds must have equal number of elements")
-	}
-	if len(hours) != len(windStates) {
-		return nil, errors.New("hours and wind states must have equal number of elements")
+	if err := rows.validate(); err != nil {
+		return nil, err
 	}
 
-	forecasts := make([]HourlyForecast, len(hours))
+	forecasts := make([]HourlyForecast, len(rows.hours))
 	for i := range forecasts {
-		forecasts[i].Timestamp = time.Date(year, month, day, hours[i], 0, 0, 0, l)
-		forecasts[i].Rating = ratings[i]
-		forecasts[i].Swells = swells[i]
-		forecasts[i].WaveEnergyInKiloJoules = waveEnergies[i]
-		forecasts[i].Wind = Wind{
-			SpeedInKilometersPerHour:     winds[i].speed,
-			DirectionToInDegrees:         winds[i].degrees,
-			DirectionFromInCompassPoints: winds[i].letters,
-			State:                        windStates[i],
+		forecasts[i].Timestamp = time.Date(year, month, day, rows.hours[i].hour, rows.hours[i].minute, 0, 0, l)
+		if rows.withRawValues {
+			forecasts[i].Raw = &RawValues{}
+		}
+		if rows.ratings != nil {
+			forecasts[i].Rating = rows.ratings[i].value
+			forecasts[i].RatingBand = rows.ratings[i].band
+			if rows.withRawValues {
+				forecasts[i].Raw.Rating = rows.ratings[i].raw
+			}
+		}
+		if rows.swells != nil {
+			forecasts[i].Swells = rows.swells[i]
+		}
+		if rows.surfHeights != nil {
+			forecasts[i].SurfHeightMinInMeters = rows.surfHeights[i].min
+			forecasts[i].SurfHeightMaxInMeters = rows.surfHeights[i].max
 		}
+		if rows.waveEnergies != nil {
+			forecasts[i].WaveEnergyInKiloJoules = rows.waveEnergies[i].value
+			if rows.withRawValues {
+				forecasts[i].Raw.WaveEnergy = rows.waveEnergies[i].raw
+			}
+		}
+		if rows.winds != nil {
+			forecasts[i].Wind.SpeedInKilometersPerHour = rows.winds[i].speed
+			forecasts[i].Wind.DirectionToInDegrees = rows.winds[i].degrees
+			forecasts[i].Wind.DirectionFromInCompassPoints = rows.winds[i].letters
+			if rows.withRawValues {
+				forecasts[i].Raw.WindSpeed = rows.winds[i].rawSpeed
+				forecasts[i].Raw.WindDirection = rows.winds[i].rawDirection
+			}
+		}
+		if rows.windStates != nil {
+			forecasts[i].Wind.State = rows.windStates[i]
+		}
+	}
+
+	var tides []Tide
+	for _, t := range rows.tides {
+		tides = append(tides, Tide{
+			Type:           t.typ,
+			Timestamp:      time.Date(year, month, day, t.hour, t.minute, 0, 0, l),
+			HeightInMeters: t.height,
+		})
 	}
 
 	return &DailyForecast{
-		Timestamp: time.Date(year, month, day, 0, 0, 0, 0, l),
-		Hourly:    forecasts,
+		Timestamp:  time.Date(year, month, day, 0, 0, 0, 0, l),
+		Hourly:     forecasts,
+		Tides:      tides,
+		Confidence: rows.confidence,
 	}, nil
 }
 
 // HourlyForecast holds a forecast for a single hour.
+//
+// The fields of HourlyForecast only hold the values scraped from
+// www.surf-forecast.com, converted to numbers and units but otherwise as the site
+// displays them. Values that the package computes from them, for instance adjusted
+// ratings, wind states or match scores, are provided by methods instead, and
+// Derived gathers the common ones. This keeps the provenance of stored forecasts
+// clear, and the derived values can be recomputed with improved formulas.
 type HourlyForecast struct {
 	// Timestamp holds a timestamp of the given forecast's day and hour.
 	Timestamp time.Time
 
 	// Rating holds a rating score ranging from 0 to 10 that represents the surf
 	// quality according to www.surf-forecast.com.
-	Rating                 int
-	Swells                 Swells
+	Rating int
+
+	// RatingBand holds the quality band that the site conveys by coloring the
+	// rating. It is RatingBandUnknown when the rating is not colored.
+	RatingBand RatingBand
+
+	Swells Swells
+
+	// SurfHeightMinInMeters and SurfHeightMaxInMeters hold the range of the surf
+	// height displayed by www.surf-forecast.com, as opposed to the heights of the
+	// individual swells. Both of them hold the same value when the site displays
+	// a single value instead of a range, and they are zero when nothing is displayed.
+	SurfHeightMinInMeters float64
+	SurfHeightMaxInMeters float64
+
 	WaveEnergyInKiloJoules float64
 	Wind                   Wind
+
+	// Raw holds the texts the parsed values of the given forecast were scraped
+	// from. It is nil unless Scraper is configured using WithRawValues, and it is
+	// not compared by Equal.
+	Raw *RawValues
+}
+
+// RawValues holds the texts that www.surf-forecast.com displays for an hourly
+// forecast as they were scraped before parsing. A value is empty when its row
+// could not be scraped.
+type RawValues struct {
+	// Rating holds the alternate text of the rating image.
+	Rating string
+
+	// WaveEnergy holds the text of the wave energy cell.
+	WaveEnergy string
+
+	// WindSpeed holds the data-speed attribute of the wind icon.
+	WindSpeed string
+
+	// WindDirection holds the transform attribute of the wind arrow.
+	WindDirection string
 }
 
 // Swells holds information about primary and secondary swells.
@@ -260,75 +816,369 @@ type Swell struct {
 	DirectionToInDegrees         float64
 	DirectionFromInCompassPoints string
 	WaveHeightInMeters           float64
+
+	// Label holds the tooltip of the swell's arrow as it is displayed by
+	// www.surf-forecast.com, for instance "2.1m 14s SW". It is empty when the
+	// arrow has no tooltip.
+	Label string
 }
 
 // Wind holds information about a wind.
 type Wind struct {
-	SpeedInKilometersPerHour     float64
-	DirectionToInDegrees         float64
+	SpeedInKilometersPerHour float64
+	DirectionToInDegrees     float64
+
+	// DirectionFromInCompassPoints holds the compass point the wind is blowing
+	// from. It is empty when www.surf-forecast.com displays no compass point,
+	// which is the case for calm winds.
 	DirectionFromInCompassPoints string
-	State                        string
+
+	State string
 }
 
-func scrapeForecast(n *html.Node, tz *timezone.Timezone) (*Forecast, error) {
-	issuedAt, err := scrapeIssueTimestamp(n, tz)
+// ScrapeForecast scrapes a forecast from the given forecast page that has already
+// been fetched and parsed, for instance a page saved to a file. It is the same
+// scraping logic that EightDaysForecast uses after fetching the page. Failures are
+// returned as ScrapeError.
+func (s *Scraper) ScrapeForecast(n *html.Node) (*Forecast, error) {
+	result, err := s.scrapeForecastPartially(n)
 	if err != nil {
-		return nil, fmt.Errorf("could not scrape issue date: %w", err)
+		return nil, err
 	}
 
-	tableNode, ok := htmlutil.FindOne(n, htmlutil.WithClassEqual(classForecastTableBasic))
-	if !ok {
-		return nil, errors.New("could not find table node")
+	if len(result.Errors) > 0 {
+		return nil, result.Errors[0]
+	}
+
+	return result.Forecast, nil
+}
+
+func (s *Scraper) scrapeForecastPartially(n *html.Node) (*ScrapeResult, error) {
+	// A custom forecast location takes precedence over the page's timezone, which
+	// in turn takes precedence over the issue text's timezone abbreviation.
+	var (
+		loc             = s.forecastLocation
+		resolveLocation = s.resolveLocation
+		pageAbbr        string
+	)
+	if loc == nil {
+		if pageLoc, ok := s.scrapePageLocation(n); ok {
+			loc = pageLoc
+			resolveLocation = func(abbr string) (*time.Location, error) {
+				pageAbbr = abbr
+				return pageLoc, nil
+			}
+		}
+	}
+
+	issuedAt, err := scrapeIssueTimestamp(n, resolveLocation, loc, s.now())
+	if err != nil {
+		return nil, &ScrapeError{Stage: StageIssueDate, Err: err}
+	}
+
+	if pageAbbr != "" {
+		s.warnOnLocationMismatch(issuedAt, pageAbbr)
+	}
+
+	if err := s.validateIssueTimestamp(issuedAt); err != nil {
+		return nil, &ScrapeError{Stage: StageIssueDate, Err: err}
+	}
+
+	tableNode, err := s.findForecastTable(n)
+	if err != nil {
+		return nil, &ScrapeError{Stage: StageTable, Err: err}
+	}
+
+	table := indexForecastTable(tableNode)
+	table.maxDays = s.maxDays
+
+	var (
+		rows forecastRows
+		errs []error
+	)
+
+	rows.withRawValues = s.rawValues
+
+	rows.days, err = scrapeDays(table)
+	if err != nil {
+		return nil, &ScrapeError{Stage: StageDays, Err: err}
+	}
+	if s.maxDays > 0 && len(rows.days) > s.maxDays {
+		rows.days = rows.days[:s.maxDays]
+	}
+
+	// The hours of one more day are scraped, so that the first day can still be
+	// told apart as partial when only a single day is scraped.
+	hoursTable := table
+	if hoursTable.maxDays > 0 {
+		hoursTable.maxDays++
+	}
+
+	rows.hours, err = scrapeHours(hoursTable)
+	if err != nil {
+		return nil, &ScrapeError{Stage: StageHours, Err: err}
+	}
+	if s.maxDays > 0 && len(rows.hours) > s.maxDays {
+		rows.nextHours = rows.hours[s.maxDays]
+		rows.hours = rows.hours[:s.maxDays]
 	}
 
-	days, err := scrapeDays(tableNode)
+	rows.ratings, err = s.scrapeRatings(table)
 	if err != nil {
-		return nil, fmt.Errorf("could not scrape days: %w", err)
+		errs = append(errs, &ScrapeError{Stage: StageRatings, Err: err})
 	}
 
-	hours, err := scrapeHours(tableNode)
+	rows.swells, err = s.scrapeSwells(table)
 	if err != nil {
-		return nil, fmt.Errorf("could not scrape hours: %w", err)
+		errs = append(errs, &ScrapeError{Stage: StageSwells, Err: err})
 	}
 
-	ratings, err := scrapeRatings(tableNode)
+	rows.surfHeights, err = s.scrapeSurfHeights(table)
 	if err != nil {
-		return nil, fmt.Errorf("could not scrape ratings: %w", err)
+		errs = append(errs, &ScrapeError{Stage: StageSurfHeights, Err: err})
 	}
 
-	swells, err := scrapeSwells(tableNode)
+	rows.waveEnergies, err = s.scrapeWaveEnergies(table)
 	if err != nil {
-		return nil, fmt.Errorf("could not scrape swells: %w", err)
+		errs = append(errs, &ScrapeError{Stage: StageWaveEnergies, Err: err})
+	}
+
+	rows.winds, err = s.scrapeWinds(table)
+	if err != nil {
+		errs = append(errs, &ScrapeError{Stage: StageWinds, Err: err})
+	}
+
+	rows.windStates, err = scrapeWindStates(table)
+	if err != nil {
+		errs = append(errs, &ScrapeError{Stage: StageWindStates, Err: err})
+	}
+
+	rows.tides, err = s.scrapeTides(table)
+	if err != nil {
+		errs = append(errs, &ScrapeError{Stage: StageTides, Err: err})
+	}
+
+	rows.confidences, err = s.scrapeConfidences(table)
+	if err != nil {
+		errs = append(errs, &ScrapeError{Stage: StageConfidences, Err: err})
+	}
+
+	if len(rows.days) == len(rows.hours) {
+		errs = append(errs, rows.dropMismatchedRows()...)
+	}
+
+	forecast, err := newForecast(issuedAt, rows)
+	if err != nil {
+		return nil, &ScrapeError{Stage: StageRows, Err: err}
+	}
+
+	forecast.Location = issuedAt.Location()
+
+	if s.consistencyChecks {
+		for _, d := range forecast.Daily {
+			if !hoursIncrease(d) {
+				s.logger.Printf("hours of %s are not in increasing order", d.Timestamp.Format("2006-01-02"))
+			}
+		}
+	}
+
+	return &ScrapeResult{
+		Forecast: forecast,
+		Errors:   errs,
+	}, nil
+}
+
+// ForecastTable is a variant of the forecast table rendered by www.surf-forecast.com.
+type ForecastTable int
+
+const (
+	// ForecastTableBasic is the basic forecast table that is rendered for all surf
+	// breaks.
+	ForecastTableBasic ForecastTable = iota
+	// ForecastTableDetailed is the detailed forecast table that is rendered only for
+	// some surf breaks and users.
+	ForecastTableDetailed
+)
+
+func (t ForecastTable) class() string {
+	if t == ForecastTableDetailed {
+		return classForecastTableDetailed
+	}
+	return classForecastTableBasic
+}
+
+// findForecastTable finds the node of the forecast table variant Scraper is configured
+// to use. When the variant is not present on the page, it falls back to the basic
+// forecast table.
+func (s *Scraper) findForecastTable(n *html.Node) (*html.Node, error) {
+	if s.forecastTable != ForecastTableBasic {
+		tableNode, ok := htmlutil.FindOne(n, htmlutil.WithClassEqual(s.forecastTable.class()))
+		if ok {
+			return tableNode, nil
+		}
+
+		s.logger.Printf("could not find %s table node, falling back to basic one", s.forecastTable.class())
+	}
+
+	tableNode, ok := htmlutil.FindOne(n, htmlutil.WithClassEqual(classForecastTableBasic))
+	if !ok {
+		return nil, errors.New("could not find table node")
+	}
+
+	return tableNode, nil
+}
+
+// forecastTable holds the rows of a forecast table indexed by their names.
+type forecastTable struct {
+	rows map[string]*html.Node
+
+	// maxDays holds the maximum number of days that get scraped from each row.
+	// All days are scraped when it is 0.
+	maxDays int
+}
+
+// errDaysExhausted is used for stopping the iteration over the cells of a row once
+// the maximum number of days has been reached.
+var errDaysExhausted = errors.New("days exhausted")
+
+// forEachCell executes the given statement for each cell of the given row until the
+// maximum number of days of the table is reached. ErrPremiumRequired is returned
+// when the row is locked behind the premium subscription.
+func (t forecastTable) forEachCell(row *html.Node, statement htmlutil.ForEachStatement) error {
+	var days int
+	err := htmlutil.ForEach(row, func(n *html.Node) error {
+		if htmlutil.ClassContains(n, classIsLocked) {
+			return ErrPremiumRequired
+		}
+
+		if !htmlutil.ClassContains(n, classForecastTableCell) {
+			return nil
+		}
+
+		if err := statement(n); err != nil {
+			return err
+		}
+
+		if htmlutil.ClassContains(n, classIsDayEnd) {
+			days++
+			if t.maxDays > 0 && days >= t.maxDays {
+				return errDaysExhausted
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errDaysExhausted) {
+		return nil
+	}
+	return err
+}
+
+// indexForecastTable indexes the rows of the given forecast table by their names,
+// so that each row can be looked up without walking through the whole table. Only
+// direct rows of the table are visited, and the content of their cells is skipped.
+// When several rows have the same name, the first one is indexed.
+func indexForecastTable(n *html.Node) forecastTable {
+	t := forecastTable{
+		rows: map[string]*html.Node{},
+	}
+	forEachForecastTableRow(n, func(name string, r *html.Node) {
+		if _, ok := t.rows[name]; !ok {
+			t.rows[name] = r
+		}
+	})
+	return t
+}
+
+// forecastTableRowNames returns the names of the given forecast table's rows in the
+// order they appear in without duplicates.
+func forecastTableRowNames(n *html.Node) []string {
+	var (
+		names []string
+		seen  = map[string]bool{}
+	)
+	forEachForecastTableRow(n, func(name string, _ *html.Node) {
+		if !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	})
+	return names
+}
+
+// forEachForecastTableRow calls the given function for each named direct row of
+// the given forecast table.
+func forEachForecastTableRow(n *html.Node, fn func(name string, r *html.Node)) {
+	tableNode := n
+	if n.DataAtom != atom.Table {
+		tableNode, _ = htmlutil.FindOne(n, htmlutil.WithElement(atom.Table))
+		if tableNode == nil {
+			return
+		}
+	}
+
+	visit := func(r *html.Node) {
+		if !htmlutil.ClassContains(r, classForecastTableRow) {
+			return
+		}
+
+		nameAttr, ok := htmlutil.Attribute(r, attributeDataRowName)
+		if !ok {
+			return
+		}
+
+		fn(nameAttr.Val, r)
+	}
+
+	for c := tableNode.FirstChild; c != nil; c = c.NextSibling {
+		switch c.DataAtom {
+		case atom.Thead, atom.Tbody, atom.Tfoot:
+			for r := c.FirstChild; r != nil; r = r.NextSibling {
+				visit(r)
+			}
+		default:
+			visit(c)
+		}
 	}
+}
 
-	waveEnergies, err := scrapeWaveEnergies(tableNode)
-	if err != nil {
-		return nil, fmt.Errorf("could not scrape wave energies: %w", err)
+// validateIssueTimestamp checks if the given issue timestamp is within the issue
+// date tolerance from the current time in case if the validation is enabled. A
+// failure is either logged or returned depending on the validation mode.
+func (s *Scraper) validateIssueTimestamp(issuedAt time.Time) error {
+	if s.issueDateValidation == ValidationOff {
+		return nil
 	}
 
-	winds, err := scrapeWinds(tableNode)
-	if err != nil {
-		return nil, fmt.Errorf("could not scrape winds: %w", err)
+	now := s.now()
+	drift := now.Sub(issuedAt)
+	if drift < 0 {
+		drift = -drift
+	}
+	if drift <= s.issueDateTolerance {
+		return nil
 	}
 
-	windStates, err := scrapeWindStates(tableNode)
-	if err != nil {
-		return nil, fmt.Errorf("could not scrape wind states: %w", err)
+	err := fmt.Errorf("%w: issued at %s, %s away from %s",
+		ErrIssueDateOutOfRange, issuedAt.Format(time.RFC3339), drift.Round(time.Minute), now.Format(time.RFC3339))
+	if s.issueDateValidation == ValidationFail {
+		return err
 	}
 
-	return newForecast(
-		issuedAt,
-		days,
-		hours,
-		ratings,
-		swells,
-		waveEnergies,
-		winds,
-		windStates,
-	)
+	s.logger.Print(err)
+	return nil
 }
 
-func scrapeIssueTimestamp(n *html.Node, tz *timezone.Timezone) (time.Time, error) {
+// scrapeIssueTimestamp scrapes the issue timestamp of the forecast. An absolute
+// issue text is resolved using its timezone abbreviation, whereas a relative one
+// carries no timezone, so it is resolved using the given location. An error
+// matching ErrTimezoneUnresolved is returned for a relative issue text when the
+// location is nil.
+func scrapeIssueTimestamp(
+	n *html.Node,
+	resolveLocation func(abbr string) (*time.Location, error),
+	loc *time.Location,
+	now time.Time) (time.Time, error) {
+
 	issueNode, ok := htmlutil.FindOne(n, htmlutil.WithClassEqual(classBreakHeaderIssued))
 	if !ok {
 		return time.Time{}, errors.New("could not find issue node")
@@ -339,9 +1189,75 @@ func scrapeIssueTimestamp(n *html.Node, tz *timezone.Timezone) (time.Time, error
 		return time.Time{}, errors.New("could not find issue text node")
 	}
 
-	parts := strings.Split(issueTextNode.Data, " ")
+	if isRelativeIssueText(issueTextNode.Data) {
+		if loc == nil {
+			return time.Time{}, fmt.Errorf(
+				"%w: relative issue text %q carries no timezone, and neither the page "+
+					"declares one nor a forecast location is set",
+				ErrTimezoneUnresolved, issueTextNode.Data,
+			)
+		}
+		return parseRelativeIssueTimestamp(issueTextNode.Data, now, loc)
+	}
+
+	return parseIssueTimestamp(issueTextNode.Data, resolveLocation)
+}
+
+// isRelativeIssueText checks if the given issue text uses relative phrasing, for
+// instance "Issued 3 hours ago", instead of an absolute timestamp.
+func isRelativeIssueText(s string) bool {
+	parts := strings.Fields(s)
+	return len(parts) > 0 && parts[len(parts)-1] == relativeIssueSuffix
+}
+
+// parseRelativeIssueTimestamp subtracts the offset of the given relative issue text
+// from the given current time, and truncates the result to the hour of the wall
+// clock of the given location.
+func parseRelativeIssueTimestamp(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	parts := strings.Fields(s)
+	if len(parts) < 3 {
+		return time.Time{}, fmt.Errorf("unexpected issue text: %q", s)
+	}
+
+	amountText := parts[len(parts)-3]
+	unitText := parts[len(parts)-2]
+
+	var amount int
+	switch amountText {
+	case "a", "an":
+		amount = 1
+	default:
+		var err error
+		amount, err = strconv.Atoi(amountText)
+		if err != nil || amount < 0 {
+			return time.Time{}, fmt.Errorf("issue offset not non-negative integer: %q", amountText)
+		}
+	}
+
+	var unit time.Duration
+	switch strings.TrimSuffix(unitText, "s") {
+	case "minute":
+		unit = time.Minute
+	case "hour":
+		unit = time.Hour
+	case "day":
+		unit = 24 * time.Hour
+	default:
+		return time.Time{}, fmt.Errorf("invalid issue offset unit: %q", unitText)
+	}
+
+	t := now.In(loc).Add(-time.Duration(amount) * unit)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc), nil
+}
+
+// parseIssueTimestamp parses the given issue text that contains an absolute timestamp.
+func parseIssueTimestamp(
+	s string,
+	resolveLocation func(abbr string) (*time.Location, error)) (time.Time, error) {
+
+	parts := strings.Split(s, " ")
 	if len(parts) != 12 {
-		return time.Time{}, fmt.Errorf("unexpected issue text: %q", issueTextNode.Data)
+		return time.Time{}, fmt.Errorf("unexpected issue text: %q", s)
 	}
 
 	hourText := parts[5]
@@ -378,20 +1294,9 @@ func scrapeIssueTimestamp(n *html.Node, tz *timezone.Timezone) (time.Time, error
 		return time.Time{}, fmt.Errorf("issue year not integer: %q", yearText)
 	}
 
-	timezones, err := tz.GetTimezones(tzAbbr)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("could not find timezones for %q abbreviation: %w", tzAbbr, err)
-	}
-
-	if len(timezones) == 0 {
-		return time.Time{}, fmt.Errorf("0 timezones found for %q abbreviation", tzAbbr)
-	}
-
-	timezone := timezones[0]
-
-	loc, err := time.LoadLocation(timezone)
+	loc, err := resolveLocation(tzAbbr)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("could not find time location for %q", timezone)
+		return time.Time{}, err
 	}
 
 	return time.Date(year, month, day, hour, 0, 0, 0, loc), nil
@@ -403,7 +1308,7 @@ func parseDay(s string) (int, error) {
 		return 0, fmt.Errorf("not integer: %q", s)
 	}
 
-	if day < 0 || day > 31 {
+	if day < 1 || day > 31 {
 		return 0, fmt.Errorf("not month day: %q", s)
 	}
 
@@ -441,26 +1346,20 @@ func parseMonthShort(s string) (time.Month, error) {
 	}
 }
 
-func scrapeDays(n *html.Node) ([]int, error) {
-	daysNode, ok := htmlutil.FindOne(
-		n,
-		htmlutil.WithClassContaining(classForecastTableRow, classForecastTableDays),
-		htmlutil.WithAttributeEqual(attributeDataRowName, dataRowNameDays),
-	)
+func scrapeDays(t forecastTable) ([]int, error) {
+	daysNode, ok := t.rows[dataRowNameDays]
 	if !ok {
 		return nil, errors.New("could not find days node")
 	}
 
 	var days []int
-	if err := htmlutil.ForEach(daysNode, func(n *html.Node) error {
-		if htmlutil.ClassContains(n, classForecastTableCell) {
-			day, err := scrapeDay(n)
-			if err != nil {
-				return fmt.Errorf("could not scrape day: %w", err)
-			}
-
-			days = append(days, day)
+	if err := t.forEachCell(daysNode, func(n *html.Node) error {
+		day, err := scrapeDay(n)
+		if err != nil {
+			return fmt.Errorf("could not scrape day: %w", err)
 		}
+
+		days = append(days, day)
 		return nil
 	}); err != nil {
 		return nil, err
@@ -469,53 +1368,79 @@ func scrapeDays(n *html.Node) ([]int, error) {
 	return days, nil
 }
 
+// scrapeDay scrapes a day of the month of the given cell. The cell holds the name
+// of the weekday and the day of the month, either in separate values or combined
+// into a single one, for instance "Tue 12". The day is found as the first number
+// in the cell's text that is a valid day of the month regardless of the cell's
+// structure, so the rest of the text, for instance decorative values, is ignored.
 func scrapeDay(n *html.Node) (int, error) {
-	nodes := htmlutil.Find(n, htmlutil.WithClassEqual(classForecastTableValue))
-	if len(nodes) != 2 {
-		return 0, errors.New("unexpected table values")
-	}
+	text := collapsedText(n)
 
-	dayTextNode := nodes[1].FirstChild
-	if dayTextNode == nil {
-		return 0, errors.New("could not find day text node")
+	numbers := strings.FieldsFunc(text, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+	for _, number := range numbers {
+		if day, err := parseDay(number); err == nil {
+			return day, nil
+		}
 	}
 
-	day, err := parseDay(dayTextNode.Data)
-	if err != nil {
-		return 0, fmt.Errorf("could not parse day: %w", err)
+	return 0, fmt.Errorf("could not find day in cell text: %q", text)
+}
+
+// scrapeValueTexts scrapes the trimmed texts of all values of the given cell in
+// the order they appear in.
+func scrapeValueTexts(n *html.Node) []string {
+	var texts []string
+	for _, node := range htmlutil.Find(n, htmlutil.WithClassEqual(classForecastTableValue)) {
+		if node.FirstChild != nil && node.FirstChild.Type == html.TextNode {
+			texts = append(texts, strings.TrimSpace(node.FirstChild.Data))
+		}
 	}
+	return texts
+}
 
-	return day, nil
+// collapsedText returns the text of the given node and all its children with the
+// whitespace collapsed into single spaces.
+func collapsedText(n *html.Node) string {
+	var ss []string
+	htmlutil.ForEach(n, func(n *html.Node) error {
+		if n.Type == html.TextNode {
+			ss = append(ss, n.Data)
+		}
+		return nil
+	})
+
+	return strings.Join(strings.Fields(strings.Join(ss, " ")), " ")
 }
 
-func scrapeHours(n *html.Node) ([][]int, error) {
-	hoursNode, ok := htmlutil.FindOne(
-		n,
-		htmlutil.WithClassContaining(classForecastTableRow, classForecastTableTime),
-		htmlutil.WithAttributeEqual(attributeDataRowName, dataRowNameTime),
-	)
+func scrapeHours(t forecastTable) ([][]clockTime, error) {
+	hoursNode, ok := t.rows[dataRowNameTime]
 	if !ok {
 		return nil, errors.New("could not find hours node")
 	}
 
 	var (
-		allHours [][]int
-		hours    []int
+		allHours [][]clockTime
+		hours    []clockTime
 	)
-	if err := htmlutil.ForEach(hoursNode, func(n *html.Node) error {
-		if htmlutil.ClassContains(n, classForecastTableCell) {
-			hour, err := scrapeHour(n)
-			if err != nil {
-				return fmt.Errorf("could not scrape hour: %w", err)
-			}
+	if err := t.forEachCell(hoursNode, func(n *html.Node) error {
+		var previous *clockTime
+		if len(hours) > 0 {
+			previous = &hours[len(hours)-1]
+		}
+
+		hour, err := scrapeHour(n, previous)
+		if err != nil {
+			return fmt.Errorf("could not scrape hour: %w", err)
+		}
 
-			hours = append(hours, hour)
+		hours = append(hours, hour)
 
-			isDayEnd := htmlutil.ClassContains(n, classIsDayEnd)
-			if isDayEnd {
-				allHours = append(allHours, hours)
-				hours = []int{}
-			}
+		isDayEnd := htmlutil.ClassContains(n, classIsDayEnd)
+		if isDayEnd {
+			allHours = append(allHours, hours)
+			hours = []clockTime{}
 		}
 		return nil
 	}); err != nil {
@@ -525,33 +1450,110 @@ func scrapeHours(n *html.Node) ([][]int, error) {
 	return allHours, nil
 }
 
-func scrapeHour(n *html.Node) (int, error) {
-	nodes := htmlutil.Find(n, htmlutil.WithClassEqual(classForecastTableValue))
-	if len(nodes) != 2 {
-		return 0, errors.New("unexpected table values")
+// clockTime holds a time of a day scraped from a forecast table.
+type clockTime struct {
+	hour   int
+	minute int
+}
+
+// scrapeHour scrapes a time of a day of the given cell. The cell holds the hour of
+// the 12-hour clock in one of its values and the clock period, "AM" or "PM", in
+// another one, so both of them are picked by their content rather than by their
+// positions. Other values are ignored. The hour may come with minutes, for instance
+// "7:30" in tables with a finer step, and the minutes default to 0 otherwise.
+//
+// The site sometimes omits the clock period, for instance for midnight in the first
+// cell of a day. A missing clock period is inferred from the given previous time of
+// the same day: the previous one's period is kept unless that would not make the
+// time come after the previous one, in which case the period after midday is used.
+// The first cell of a day, which has no previous time, is before midday, so "12"
+// results in 0. A time that cannot come after a previous time past midday, for
+// instance "12" after "3 PM", results in an error since midnight belongs to the
+// next day.
+func scrapeHour(n *html.Node, previous *clockTime) (clockTime, error) {
+	var (
+		texts       = scrapeValueTexts(n)
+		hour        int
+		minute      int
+		foundHour   bool
+		period      clockPeriod
+		foundPeriod bool
+	)
+	for _, text := range texts {
+		if !foundHour {
+			if h, m, err := parseTwelveClockTime(text); err == nil {
+				hour, minute, foundHour = h, m, true
+				continue
+			}
+		}
+		if p, err := parseClockPeriod(text); err == nil {
+			period, foundPeriod = p, true
+		}
+	}
+
+	if !foundHour {
+		return clockTime{}, fmt.Errorf("could not find hour among table values: %q", texts)
+	}
+
+	if !foundPeriod {
+		p, err := inferClockPeriod(hour, minute, previous)
+		if err != nil {
+			return clockTime{}, err
+		}
+		period = p
+	}
+
+	return clockTime{
+		hour:   toTwentyFourClockHour(hour, period),
+		minute: minute,
+	}, nil
+}
+
+// inferClockPeriod infers the clock period of the given 12-hour clock time that
+// comes after the given previous time of the same day, if any. An error is
+// returned when neither of the clock periods makes the time come after the
+// previous one.
+func inferClockPeriod(hour, minute int, previous *clockTime) (clockPeriod, error) {
+	if previous == nil {
+		return beforeMidday, nil
+	}
+
+	morning := toTwentyFourClockHour(hour, beforeMidday)*60 + minute
+	if morning > previous.hour*60+previous.minute {
+		return beforeMidday, nil
 	}
 
-	hourTextNode := nodes[0].FirstChild
-	if hourTextNode == nil {
-		return 0, errors.New("could not find hour text node")
+	afternoon := toTwentyFourClockHour(hour, afterMidday)*60 + minute
+	if afternoon > previous.hour*60+previous.minute {
+		return afterMidday, nil
 	}
 
-	hour, err := parseTwelveClockHour(hourTextNode.Data)
+	return 0, fmt.Errorf(
+		"could not infer clock period of %d:%02d following %02d:%02d",
+		hour, minute, previous.hour, previous.minute,
+	)
+}
+
+// parseTwelveClockTime parses an hour of the 12-hour clock that is optionally
+// followed by minutes, for instance "7" or "7:30".
+func parseTwelveClockTime(s string) (int, int, error) {
+	parts := strings.SplitN(s, ":", 2)
+
+	hour, err := parseTwelveClockHour(parts[0])
 	if err != nil {
-		return 0, fmt.Errorf("could not parse hour: %w", err)
+		return 0, 0, err
 	}
 
-	periodTextNode := nodes[1].FirstChild
-	if periodTextNode == nil {
-		return 0, errors.New("could not find clock period text node")
+	if len(parts) == 1 {
+		return hour, 0, nil
 	}
 
-	period, err := parseClockPeriod(periodTextNode.Data)
+	minute, err := parseMinute(parts[1])
 	if err != nil {
-		return 0, fmt.Errorf("could not parse clock period: %w", err)
+		return 0, 0, err
 	}
 
-	return toTwentyFourClockHour(hour, period), nil
+	return hour, minute, nil
 }
 
 func parseTwelveClockHour(s string) (int, error) {
@@ -575,7 +1577,7 @@ const (
 )
 
 func parseClockPeriod(s string) (clockPeriod, error) {
-	switch strings.ToUpper(s) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
 	case "AM":
 		return beforeMidday, nil
 	case "PM":
@@ -585,6 +1587,8 @@ func parseClockPeriod(s string) (clockPeriod, error) {
 	}
 }
 
+// toTwentyFourClockHour converts the given 12-hour clock hour to a 24-hour clock
+// one, for instance 12 AM to 0, 1 AM to 1, 12 PM to 12 and 11 PM to 23.
 func toTwentyFourClockHour(hour int, p clockPeriod) int {
 	if p == beforeMidday {
 		if hour == 12 {
@@ -598,39 +1602,46 @@ func toTwentyFourClockHour(hour int, p clockPeriod) int {
 	return hour + 12
 }
 
-func scrapeRatings(n *html.Node) ([][]int, error) {
-	ratingsNode, ok := htmlutil.FindOne(
-		n,
-		htmlutil.WithClassContaining(classForecastTableRow, classForecastTableRating),
-		htmlutil.WithAttributeEqual(attributeDataRowName, dataRowNameRating),
-	)
+func (s *Scraper) scrapeRatings(t forecastTable) ([][]rating, error) {
+	ratingsNode, ok := t.rows[dataRowNameRating]
 	if !ok {
 		return nil, errors.New("could not find ratings node")
 	}
 
 	var (
-		allRatings [][]int
-		ratings    []int
+		allRatings [][]rating
+		ratings    []rating
 	)
-	if err := htmlutil.ForEach(ratingsNode, func(n *html.Node) error {
-		if htmlutil.ClassContains(n, classForecastTableCell) {
-			ratingAttr, ok := htmlutil.Attribute(n.FirstChild, htmlutil.AttributeAlternateImageText)
-			if !ok {
-				return errors.New("could not find rating attribute")
-			}
+	if err := t.forEachCell(ratingsNode, func(n *html.Node) error {
+		ratingNode, ok := htmlutil.FirstElementChild(n)
+		if !ok {
+			return errors.New("could not find rating node")
+		}
+
+		ratingAttr, ok := htmlutil.Attribute(ratingNode, htmlutil.AttributeAlternateImageText)
+		if !ok {
+			return errors.New("could not find rating attribute")
+		}
 
-			rating, err := parseRating(ratingAttr.Val)
+		value, err := parseRating(ratingAttr.Val)
+		if err != nil {
+			clamped, err := s.tolerateOutOfRange(err)
 			if err != nil {
 				return fmt.Errorf("could not parse rating: %w", err)
 			}
+			value = int(clamped)
+		}
 
-			ratings = append(ratings, rating)
+		ratings = append(ratings, rating{
+			value: value,
+			band:  scrapeRatingBand(n, ratingNode),
+			raw:   ratingAttr.Val,
+		})
 
-			isDayEnd := htmlutil.ClassContains(n, classIsDayEnd)
-			if isDayEnd {
-				allRatings = append(allRatings, ratings)
-				ratings = []int{}
-			}
+		isDayEnd := htmlutil.ClassContains(n, classIsDayEnd)
+		if isDayEnd {
+			allRatings = append(allRatings, ratings)
+			ratings = []rating{}
 		}
 		return nil
 	}); err != nil {
@@ -640,25 +1651,27 @@ func scrapeRatings(n *html.Node) ([][]int, error) {
 	return allRatings, nil
 }
 
+type rating struct {
+	value int
+	band  RatingBand
+	raw   string
+}
+
 func parseRating(s string) (int, error) {
 	rating, err := strconv.Atoi(s)
 	if err != nil {
 		return 0, fmt.Errorf("not integer: %q", s)
 	}
 
-	if rating < 0 || rating > 10 {
-		return 0, fmt.Errorf("invalid rating: %q", s)
+	if rating < minRating || rating > maxRating {
+		return 0, newOutOfRangeError("rating", s, float64(rating), minRating, maxRating)
 	}
 
 	return rating, nil
 }
 
-func scrapeSwells(n *html.Node) ([][]Swells, error) {
-	swellsNode, ok := htmlutil.FindOne(
-		n,
-		htmlutil.WithClassEqual(classForecastTableRow),
-		htmlutil.WithAttributeEqual(attributeDataRowName, dataRowNameWaveHeight),
-	)
+func (s *Scraper) scrapeSwells(t forecastTable) ([][]Swells, error) {
+	swellsNode, ok := t.rows[dataRowNameWaveHeight]
 	if !ok {
 		return nil, errors.New("could not find swells node")
 	}
@@ -667,28 +1680,40 @@ func scrapeSwells(n *html.Node) ([][]Swells, error) {
 		allSwells [][]Swells
 		swells    []Swells
 	)
-	if err := htmlutil.ForEach(swellsNode, func(n *html.Node) error {
-		if htmlutil.ClassContains(n, classForecastTableCell) {
-			hourlySwells, err := scrapeHourlySwells(n)
-			if err != nil {
-				return fmt.Errorf("could not scrape hourly swells: %w", err)
-			}
+	if err := t.forEachCell(swellsNode, func(n *html.Node) error {
+		hourlySwells, err := scrapeHourlySwells(n)
+		if err != nil {
+			return fmt.Errorf("could not scrape hourly swells: %w", err)
+		}
 
-			if len(hourlySwells) == 0 {
-				return fmt.Errorf("no swells")
-			}
+		if len(hourlySwells) == 0 {
+			return fmt.Errorf("no swells")
+		}
 
-			swells = append(swells, Swells{
-				Primary:   hourlySwells[0],
-				Secondary: hourlySwells[1:],
-			})
+		arrowNodes := htmlutil.Find(n, htmlutil.WithClassEqual(classSwellIconArrow))
+		for i := 0; i < len(arrowNodes) && i < len(hourlySwells); i++ {
+			hourlySwells[i].Label = scrapeSwellLabel(arrowNodes[i])
+		}
 
-			isDayEnd := htmlutil.ClassContains(n, classIsDayEnd)
-			if isDayEnd {
-				allSwells = append(allSwells, swells)
-				swells = []Swells{}
+		if s.consistencyChecks {
+			if len(arrowNodes) != len(hourlySwells) {
+				s.logger.Printf(
+					"number of swells %d does not match number of swell arrows %d",
+					len(hourlySwells), len(arrowNodes),
+				)
 			}
 		}
+
+		swells = append(swells, Swells{
+			Primary:   hourlySwells[0],
+			Secondary: largestSwells(hourlySwells[1:], s.maxSwellsPerHour-1),
+		})
+
+		isDayEnd := htmlutil.ClassContains(n, classIsDayEnd)
+		if isDayEnd {
+			allSwells = append(allSwells, swells)
+			swells = []Swells{}
+		}
 		return nil
 	}); err != nil {
 		return nil, err
@@ -697,24 +1722,69 @@ func scrapeSwells(n *html.Node) ([][]Swells, error) {
 	return allSwells, nil
 }
 
+// largestSwells returns the given number of the given swells with the largest wave
+// heights in their original order. All swells are returned when the number is
+// negative.
+func largestSwells(swells []Swell, n int) []Swell {
+	if n < 0 || len(swells) <= n {
+		return swells
+	}
+
+	indexes := make([]int, len(swells))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return swells[indexes[i]].WaveHeightInMeters > swells[indexes[j]].WaveHeightInMeters
+	})
+
+	indexes = indexes[:n]
+	sort.Ints(indexes)
+
+	largest := make([]Swell, n)
+	for i, index := range indexes {
+		largest[i] = swells[index]
+	}
+	return largest
+}
+
 func scrapeHourlySwells(n *html.Node) ([]Swell, error) {
 	attr, ok := htmlutil.Attribute(n, attributeDataSwellState)
 	if !ok {
 		return nil, errors.New("could not find swells attribute")
 	}
 
-	swells, err := unmarshalSwells([]byte(attr.Val))
+	swells, err := DecodeSwells([]byte(attr.Val))
 	if err != nil {
-		return nil, fmt.Errorf("could not unmarshal swells: %w", err)
+		return nil, fmt.Errorf("could not decode swells: %w", err)
 	}
 
 	return swells, nil
 }
 
-func unmarshalSwells(b []byte) ([]Swell, error) {
+// scrapeSwellLabel scrapes the tooltip of the given swell arrow which is held either
+// in its title attribute or in its title element. An empty string is returned when
+// the arrow has no tooltip.
+func scrapeSwellLabel(n *html.Node) string {
+	if attr, ok := htmlutil.Attribute(n, htmlutil.AttributeTitle); ok {
+		return strings.TrimSpace(attr.Val)
+	}
+
+	titleNode, ok := htmlutil.FindOne(n, htmlutil.WithElement(atom.Title))
+	if !ok || titleNode.FirstChild == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(titleNode.FirstChild.Data)
+}
+
+// DecodeSwells decodes swells from the JSON payload that www.surf-forecast.com
+// holds in the data-swell-state attribute of the forecast table's cells. Null
+// entries of the payload are skipped.
+func DecodeSwells(b []byte) ([]Swell, error) {
 	var payload []*swell
 	if err := json.Unmarshal(b, &payload); err != nil {
-		return nil, fmt.Errorf("could not unmarshal payload: %w", err)
+		return nil, fmt.Errorf("malformed swells payload: %w", err)
 	}
 
 	var swells []Swell
@@ -741,34 +1811,143 @@ type swell struct {
 	Height  float64 `json:"height"`
 }
 
-func scrapeWaveEnergies(n *html.Node) ([][]float64, error) {
-	energiesNode, ok := htmlutil.FindOne(
-		n,
-		htmlutil.WithClassEqual(classForecastTableRow),
-		htmlutil.WithAttributeEqual(attributeDataRowName, dataRowNameEnergy),
+func (s *Scraper) scrapeSurfHeights(t forecastTable) ([][]surfHeight, error) {
+	heightsNode, ok := t.rows[dataRowNameWaveHeight]
+	if !ok {
+		return nil, errors.New("could not find surf heights node")
+	}
+
+	var (
+		allHeights [][]surfHeight
+		heights    []surfHeight
 	)
+	if err := t.forEachCell(heightsNode, func(n *html.Node) error {
+		// A surf height that cannot be parsed only loses a single hour, so it is
+		// logged and left empty rather than failing the whole row.
+		h, err := scrapeSurfHeight(n, s.numberFormat)
+		if err != nil {
+			s.logger.Printf("could not scrape surf height: %v", err)
+		}
+
+		heights = append(heights, h)
+
+		isDayEnd := htmlutil.ClassContains(n, classIsDayEnd)
+		if isDayEnd {
+			allHeights = append(allHeights, heights)
+			heights = []surfHeight{}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return allHeights, nil
+}
+
+type surfHeight struct {
+	min float64
+	max float64
+}
+
+func scrapeSurfHeight(n *html.Node, f NumberFormat) (surfHeight, error) {
+	valueNode, ok := htmlutil.FindOne(n, htmlutil.WithClassEqual(classForecastTableValue))
+	if !ok {
+		return surfHeight{}, nil
+	}
+
+	var ss []string
+	htmlutil.ForEach(valueNode, func(n *html.Node) error {
+		if n.Type == html.TextNode {
+			ss = append(ss, n.Data)
+		}
+		return nil
+	})
+
+	text := strings.TrimSpace(strings.Join(ss, ""))
+	if text == "" {
+		return surfHeight{}, nil
+	}
+
+	return parseSurfHeight(text, f)
+}
+
+// parseSurfHeight parses either a single surf height, for instance "1.5", or a
+// range of surf heights, for instance "1.2-1.8", written in the given number format.
+// The heights may carry a unit suffix, for instance "1.5m" or "4-6ft", in which
+// case heights in feet are converted into meters.
+func parseSurfHeight(s string, f NumberFormat) (surfHeight, error) {
+	parts := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '-' || r == '–'
+	})
+	if len(parts) != 1 && len(parts) != 2 {
+		return surfHeight{}, fmt.Errorf("invalid surf height: %q", s)
+	}
+
+	factor := 1.0
+	if strings.HasSuffix(strings.ToLower(strings.TrimSpace(s)), "ft") {
+		factor = metersPerFoot
+	}
+
+	var heights []float64
+	for _, p := range parts {
+		h, err := f.parseFloat(trimLengthUnit(p))
+		if err != nil {
+			return surfHeight{}, err
+		}
+		h *= factor
+
+		if h < 0 {
+			return surfHeight{}, fmt.Errorf("invalid surf height: %q", s)
+		}
+
+		heights = append(heights, h)
+	}
+
+	if len(heights) == 1 {
+		return surfHeight{min: heights[0], max: heights[0]}, nil
+	}
+	return surfHeight{min: heights[0], max: heights[1]}, nil
+}
+
+// trimLengthUnit trims a trailing "m" or "ft" unit off the given length.
+func trimLengthUnit(s string) string {
+	s = strings.TrimSpace(s)
+	lower := strings.ToLower(s)
+	switch {
+	case strings.HasSuffix(lower, "ft"):
+		s = s[:len(s)-len("ft")]
+	case strings.HasSuffix(lower, "m"):
+		s = s[:len(s)-len("m")]
+	}
+	return strings.TrimSpace(s)
+}
+
+func (s *Scraper) scrapeWaveEnergies(t forecastTable) ([][]waveEnergy, error) {
+	energiesNode, ok := t.rows[dataRowNameEnergy]
 	if !ok {
 		return nil, errors.New("could not find wave energies node")
 	}
 
+	if err := validateWaveEnergyUnit(energiesNode); err != nil {
+		s.logger.Printf("wave energies might not be in kilojoules: %v", err)
+	}
+
 	var (
-		allEnergies [][]float64
-		energies    []float64
+		allEnergies [][]waveEnergy
+		energies    []waveEnergy
 	)
-	if err := htmlutil.ForEach(energiesNode, func(n *html.Node) error {
-		if htmlutil.ClassContains(n, classForecastTableCell) {
-			energy, err := scrapeWaveEnergy(n)
-			if err != nil {
-				return fmt.Errorf("could not scrape wave energy: %w", err)
-			}
+	if err := t.forEachCell(energiesNode, func(n *html.Node) error {
+		energy, err := s.scrapeWaveEnergy(n)
+		if err != nil {
+			return fmt.Errorf("could not scrape wave energy: %w", err)
+		}
 
-			energies = append(energies, energy)
+		energies = append(energies, energy)
 
-			isDayEnd := htmlutil.ClassContains(n, classIsDayEnd)
-			if isDayEnd {
-				allEnergies = append(allEnergies, energies)
-				energies = []float64{}
-			}
+		isDayEnd := htmlutil.ClassContains(n, classIsDayEnd)
+		if isDayEnd {
+			allEnergies = append(allEnergies, energies)
+			energies = []waveEnergy{}
 		}
 		return nil
 	}); err != nil {
@@ -778,44 +1957,76 @@ func scrapeWaveEnergies(n *html.Node) ([][]float64, error) {
 	return allEnergies, nil
 }
 
-func scrapeWaveEnergy(n *html.Node) (float64, error) {
-	energyNode := n.FirstChild
-	if energyNode == nil {
-		return 0, errors.New("could not find wave energy node")
+// validateWaveEnergyUnit checks that the header of the given wave energies row
+// mentions the unit the wave energies are expected to be displayed in. The check
+// is skipped when the row has no header.
+func validateWaveEnergyUnit(n *html.Node) error {
+	headerNode, ok := htmlutil.FindOne(n, htmlutil.WithElement(atom.Th))
+	if !ok {
+		return nil
+	}
+
+	var ss []string
+	htmlutil.ForEach(headerNode, func(n *html.Node) error {
+		if n.Type == html.TextNode {
+			ss = append(ss, n.Data)
+		}
+		return nil
+	})
+
+	header := strings.Join(strings.Fields(strings.Join(ss, " ")), " ")
+	if !strings.Contains(header, waveEnergyUnit) {
+		return fmt.Errorf("unexpected wave energy unit: %q", header)
+	}
+
+	return nil
+}
+
+func (s *Scraper) scrapeWaveEnergy(n *html.Node) (waveEnergy, error) {
+	energyNode, ok := htmlutil.FirstElementChild(n)
+	if !ok {
+		return waveEnergy{}, errors.New("could not find wave energy node")
 	}
 
 	energyTextNode := energyNode.FirstChild
 	if energyTextNode == nil {
-		return 0, errors.New("could not find wave energy text node")
+		return waveEnergy{}, errors.New("could not find wave energy text node")
 	}
 
-	energy, err := parseWaveEnergy(energyTextNode.Data)
+	energy, err := parseWaveEnergy(energyTextNode.Data, s.numberFormat)
 	if err != nil {
-		return 0, fmt.Errorf("could not parse wave energy: %w", err)
+		energy, err = s.tolerateOutOfRange(err)
+		if err != nil {
+			return waveEnergy{}, fmt.Errorf("could not parse wave energy: %w", err)
+		}
 	}
 
-	return energy, nil
+	return waveEnergy{
+		value: energy,
+		raw:   energyTextNode.Data,
+	}, nil
 }
 
-func parseWaveEnergy(s string) (float64, error) {
-	energy, err := strconv.ParseFloat(s, 64)
+type waveEnergy struct {
+	value float64
+	raw   string
+}
+
+func parseWaveEnergy(s string, f NumberFormat) (float64, error) {
+	energy, err := f.parseFloat(s)
 	if err != nil {
-		return 0, fmt.Errorf("not float: %q", s)
+		return 0, err
 	}
 
 	if energy < 0 {
-		return 0, fmt.Errorf("invalid wave energy: %q", s)
+		return 0, newOutOfRangeError("wave energy", s, energy, 0, math.Inf(1))
 	}
 
 	return energy, nil
 }
 
-func scrapeWinds(n *html.Node) ([][]wind, error) {
-	windsNode, ok := htmlutil.FindOne(
-		n,
-		htmlutil.WithClassEqual(classForecastTableRow),
-		htmlutil.WithAttributeEqual(attributeDataRowName, dataRowNameWind),
-	)
+func (s *Scraper) scrapeWinds(t forecastTable) ([][]wind, error) {
+	windsNode, ok := t.rows[dataRowNameWind]
 	if !ok {
 		return nil, errors.New("could not find winds node")
 	}
@@ -824,20 +2035,18 @@ func scrapeWinds(n *html.Node) ([][]wind, error) {
 		allWinds [][]wind
 		winds    []wind
 	)
-	if err := htmlutil.ForEach(windsNode, func(n *html.Node) error {
-		if htmlutil.ClassContains(n, classForecastTableCell) {
-			w, err := scrapeWind(n)
-			if err != nil {
-				return fmt.Errorf("could not scrape wind: %w", err)
-			}
+	if err := t.forEachCell(windsNode, func(n *html.Node) error {
+		w, err := s.scrapeWind(n)
+		if err != nil {
+			return fmt.Errorf("could not scrape wind: %w", err)
+		}
 
-			winds = append(winds, w)
+		winds = append(winds, w)
 
-			isDayEnd := htmlutil.ClassContains(n, classIsDayEnd)
-			if isDayEnd {
-				allWinds = append(allWinds, winds)
-				winds = []wind{}
-			}
+		isDayEnd := htmlutil.ClassContains(n, classIsDayEnd)
+		if isDayEnd {
+			allWinds = append(allWinds, winds)
+			winds = []wind{}
 		}
 		return nil
 	}); err != nil {
@@ -847,7 +2056,7 @@ func scrapeWinds(n *html.Node) ([][]wind, error) {
 	return allWinds, nil
 }
 
-func scrapeWind(n *html.Node) (wind, error) {
+func (s *Scraper) scrapeWind(n *html.Node) (wind, error) {
 	iconNode, ok := htmlutil.FindOne(n, htmlutil.WithClassEqual(classWindIcon))
 	if !ok {
 		return wind{}, errors.New("could not find wind icon node")
@@ -858,78 +2067,148 @@ func scrapeWind(n *html.Node) (wind, error) {
 		return wind{}, errors.New("could not find wind speed attribute")
 	}
 
-	speed, err := parseWindSpeed(speedAttr.Val)
+	speed, err := parseWindSpeed(speedAttr.Val, s.numberFormat)
 	if err != nil {
 		return wind{}, fmt.Errorf("could not parse wind speed: %w", err)
 	}
 
-	degrees, err := scrapeWindDirectionDegrees(iconNode)
-	if err != nil {
+	// Calm winds are rendered without an arrow, so their direction is left at 0
+	// when it cannot be scraped.
+	degrees, degreesAttr, err := s.scrapeWindDirectionDegrees(iconNode)
+	if err != nil && speed >= calmWindSpeed {
 		return wind{}, fmt.Errorf("could not scrape wind direction degrees: %w", err)
 	}
 
-	lettersNode, ok := htmlutil.FindOne(iconNode, htmlutil.WithClassEqual(classWindLetters))
+	letters := scrapeWindLetters(iconNode)
+	if speed >= calmWindSpeed && !windDirectionsAgree(degrees, letters) {
+		s.logger.Printf(
+			"wind direction %q does not match wind arrow rotated by %v degrees",
+			letters, degrees,
+		)
+	}
+
+	return wind{
+		speed:        speed,
+		degrees:      degrees,
+		letters:      letters,
+		rawSpeed:     speedAttr.Val,
+		rawDirection: degreesAttr,
+	}, nil
+}
+
+// scrapeWindLetters scrapes the compass point the wind is blowing from. The site
+// renders calm winds without the compass point, so an empty string is returned
+// when it is missing instead of failing the whole row.
+func scrapeWindLetters(n *html.Node) string {
+	lettersNode, ok := htmlutil.FindOne(n, htmlutil.WithClassEqual(classWindLetters))
 	if !ok {
-		return wind{}, errors.New("could not find wind direction letters node")
+		return ""
 	}
 
 	lettersTextNode := lettersNode.FirstChild
 	if lettersTextNode == nil {
-		return wind{}, errors.New("could not find wind direction letters text node")
+		return ""
 	}
 
-	return wind{
-		speed:   speed,
-		degrees: degrees,
-		letters: lettersTextNode.Data,
-	}, nil
+	return lettersTextNode.Data
 }
 
 type wind struct {
-	speed   float64
-	degrees float64
-	letters string
+	speed        float64
+	degrees      float64
+	letters      string
+	rawSpeed     string
+	rawDirection string
 }
 
-func scrapeWindDirectionDegrees(n *html.Node) (float64, error) {
+// scrapeWindDirectionDegrees scrapes the rotation of the wind arrow in degrees
+// along with the raw transform attribute it was parsed from.
+func (s *Scraper) scrapeWindDirectionDegrees(n *html.Node) (float64, string, error) {
 	arrowNode, ok := htmlutil.FindOne(n, htmlutil.WithClassEqual(classWindIconArrow))
 	if !ok {
-		return 0, errors.New("could not find wind direction arrow node")
+		return 0, "", errors.New("could not find wind direction arrow node")
 	}
 
 	attr, ok := htmlutil.Attribute(arrowNode, htmlutil.AttributeTransform)
 	if !ok {
-		return 0, errors.New("could not find transform attribute")
+		return 0, "", errors.New("could not find transform attribute")
 	}
 
 	degreesText := strings.TrimPrefix(attr.Val, transformRotatePrefix)
 	degreesText = strings.TrimSuffix(degreesText, transformRotateSuffix)
 
-	degrees, err := parseWindDirectionDegrees(degreesText)
+	degrees, err := parseWindDirectionDegrees(degreesText, s.numberFormat)
 	if err != nil {
-		return 0, fmt.Errorf("could not parse wind direction degrees: %w", err)
+		degrees, err = s.tolerateOutOfRange(err)
+		if err != nil {
+			return 0, "", fmt.Errorf("could not parse wind direction degrees: %w", err)
+		}
 	}
 
-	return degrees, nil
+	// 360 degrees is the same bearing as 0 degrees, so it is normalized in order
+	// to keep the degrees within [0, 360).
+	return math.Mod(degrees, 360), attr.Val, nil
 }
 
-func parseWindDirectionDegrees(s string) (float64, error) {
-	degrees, err := strconv.ParseFloat(s, 64)
+func parseWindDirectionDegrees(s string, f NumberFormat) (float64, error) {
+	degrees, err := f.parseFloat(s)
 	if err != nil {
-		return 0, fmt.Errorf("not float: %q", s)
+		return 0, err
 	}
 
 	if degrees < 0 || degrees > 360 {
-		return 0, fmt.Errorf("invalid wind direction degrees: %q", s)
+		return 0, newOutOfRangeError("wind direction degrees", s, degrees, 0, 360)
 	}
 
 	return degrees, nil
 }
 
-func parseWindSpeed(s string) (float64, error) {
-	speed, err := strconv.ParseFloat(s, 64)
+// outOfRangeError is returned when a scraped value is parsed successfully but falls
+// outside of the range of values it is expected to have.
+type outOfRangeError struct {
+	name     string
+	text     string
+	value    float64
+	min, max float64
+}
+
+func newOutOfRangeError(name, text string, value, min, max float64) *outOfRangeError {
+	return &outOfRangeError{
+		name:  name,
+		text:  text,
+		value: value,
+		min:   min,
+		max:   max,
+	}
+}
+
+func (e *outOfRangeError) Error() string {
+	return fmt.Sprintf("invalid %s: %q", e.name, e.text)
+}
+
+// clamped returns the value clamped to the expected range.
+func (e *outOfRangeError) clamped() float64 {
+	return math.Max(e.min, math.Min(e.max, e.value))
+}
+
+// tolerateOutOfRange returns the clamped value of the given error in case if it is
+// an out-of-range error and lenient parsing is enabled, and logs a warning about
+// it. Otherwise, the error is returned as is.
+func (s *Scraper) tolerateOutOfRange(err error) (float64, error) {
+	var rangeErr *outOfRangeError
+	if !s.lenientParsing || !errors.As(err, &rangeErr) {
+		return 0, err
+	}
+
+	clamped := rangeErr.clamped()
+	s.logger.Printf("clamping %s %q to %v", rangeErr.name, rangeErr.text, clamped)
+	return clamped, nil
+}
+
+func parseWindSpeed(s string, f NumberFormat) (float64, error) {
+	speed, err := f.parseFloat(s)
 	if err != nil {
-		return 0, fmt.Errorf("not float: %q", s)
+		return 0, err
 	}
 
 	if speed < 0 {
@@ -939,12 +2218,8 @@ func parseWindSpeed(s string) (float64, error) {
 	return speed, nil
 }
 
-func scrapeWindStates(n *html.Node) ([][]string, error) {
-	statesNode, ok := htmlutil.FindOne(
-		n,
-		htmlutil.WithClassEqual(classForecastTableRow),
-		htmlutil.WithAttributeEqual(attributeDataRowName, dataRowNameWindState),
-	)
+func scrapeWindStates(t forecastTable) ([][]string, error) {
+	statesNode, ok := t.rows[dataRowNameWindState]
 	if !ok {
 		return nil, errors.New("could not find wind states node")
 	}
@@ -953,20 +2228,18 @@ func scrapeWindStates(n *html.Node) ([][]string, error) {
 		allStates [][]string
 		states    []string
 	)
-	if err := htmlutil.ForEach(statesNode, func(n *html.Node) error {
-		if htmlutil.ClassContains(n, classForecastTableCell) {
-			state, err := scrapeWindState(n)
-			if err != nil {
-				return fmt.Errorf("could not scrape wind state: %w", err)
-			}
+	if err := t.forEachCell(statesNode, func(n *html.Node) error {
+		state, err := scrapeWindState(n)
+		if err != nil {
+			return fmt.Errorf("could not scrape wind state: %w", err)
+		}
 
-			states = append(states, state)
+		states = append(states, state)
 
-			isDayEnd := htmlutil.ClassContains(n, classIsDayEnd)
-			if isDayEnd {
-				allStates = append(allStates, states)
-				states = []string{}
-			}
+		isDayEnd := htmlutil.ClassContains(n, classIsDayEnd)
+		if isDayEnd {
+			allStates = append(allStates, states)
+			states = []string{}
 		}
 		return nil
 	}); err != nil {
@@ -977,15 +2250,9 @@ func scrapeWindStates(n *html.Node) ([][]string, error) {
 }
 
 func scrapeWindState(n *html.Node) (string, error) {
-	var ss []string
-	htmlutil.ForEach(n, func(n *html.Node) error {
-		if n.Type == html.TextNode {
-			ss = append(ss, n.Data)
-		}
-		return nil
-	})
-
-	state := strings.Join(ss, "")
+	// The text of a wind state might be split into multiple text nodes that are
+	// indented and spread across multiple lines of the markup.
+	state := collapsedText(n)
 	if state == "" {
 		return "", errors.New("invalid wind state")
 	}
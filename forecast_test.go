@@ -0,0 +1,602 @@
+package surfforecast
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ztimes2/surfforecast-go/internal/htmlutil"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func mustParseHTML(t *testing.T, s string) *html.Node {
+	t.Helper()
+
+	n, err := html.Parse(strings.NewReader(s))
+	if err != nil {
+		t.Fatalf("could not parse html: %v", err)
+	}
+	return n
+}
+
+// mustIndexForecastTable indexes a forecast table made of the given rows.
+func mustIndexForecastTable(t *testing.T, rows string) forecastTable {
+	t.Helper()
+
+	n := mustParseHTML(t, `<table class="forecast-table__basic"><tbody>`+rows+`</tbody></table>`)
+	tableNode, ok := htmlutil.FindOne(n, htmlutil.WithElement(atom.Table))
+	if !ok {
+		t.Fatal("could not find table node")
+	}
+	return indexForecastTable(tableNode)
+}
+
+func TestScrapeIssueTimestamp_Relative(t *testing.T) {
+	var (
+		wita = time.FixedZone("WITA", 8*60*60)
+		now  = time.Date(2021, time.October, 31, 20, 30, 0, 0, time.UTC)
+		page = `<div class="break-header__issued">Issued 3 hours ago</div>`
+	)
+
+	noAbbreviation := func(abbr string) (*time.Location, error) {
+		t.Fatalf("unexpected abbreviation lookup: %q", abbr)
+		return nil, nil
+	}
+
+	t.Run("known location", func(t *testing.T) {
+		got, err := scrapeIssueTimestamp(mustParseHTML(t, page), noAbbreviation, wita, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// 20:30 UTC is 04:30 of the next day in WITA, so the local date rolls
+		// over into the next month.
+		want := time.Date(2021, time.November, 1, 1, 0, 0, 0, wita)
+		if !got.Equal(want) || got.Location() != wita {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("unknown location", func(t *testing.T) {
+		_, err := scrapeIssueTimestamp(mustParseHTML(t, page), noAbbreviation, nil, now)
+		if !errors.Is(err, ErrTimezoneUnresolved) {
+			t.Errorf("got %v, want %v", err, ErrTimezoneUnresolved)
+		}
+	})
+}
+
+func TestForecastRows_DropMismatchedRows(t *testing.T) {
+	rows := forecastRows{
+		days:    []int{1, 2},
+		hours:   [][]clockTime{{{hour: 0}, {hour: 3}}, {{hour: 0}}},
+		ratings: [][]rating{make([]rating, 2), make([]rating, 1)},
+		// The second day is missing an hour.
+		winds: [][]wind{make([]wind, 2), make([]wind, 0)},
+		// The second day is missing altogether.
+		tides: [][]tide{nil},
+	}
+
+	errs := rows.dropMismatchedRows()
+
+	if rows.ratings == nil {
+		t.Error("ratings have been dropped")
+	}
+	if rows.winds != nil {
+		t.Error("winds have not been dropped")
+	}
+	if rows.tides != nil {
+		t.Error("tides have not been dropped")
+	}
+
+	if len(errs) != 2 {
+		t.Fatalf("got %d errors, want 2: %v", len(errs), errs)
+	}
+	for i, stage := range []string{StageWinds, StageTides} {
+		var scrapeErr *ScrapeError
+		if !errors.As(errs[i], &scrapeErr) || scrapeErr.Stage != stage {
+			t.Errorf("error %d: got %v, want stage %q", i, errs[i], stage)
+		}
+	}
+
+	if _, err := newForecast(time.Date(2021, time.November, 1, 0, 0, 0, 0, time.UTC), rows); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestParseSurfHeight(t *testing.T) {
+	tests := []struct {
+		text    string
+		format  NumberFormat
+		want    surfHeight
+		wantErr bool
+	}{
+		{text: "1.5", want: surfHeight{min: 1.5, max: 1.5}},
+		{text: "1.2-1.8", want: surfHeight{min: 1.2, max: 1.8}},
+		{text: "1.5m", want: surfHeight{min: 1.5, max: 1.5}},
+		{text: "1.2m-1.8m", want: surfHeight{min: 1.2, max: 1.8}},
+		{text: "1,2–1,8 m", format: NumberFormatComma, want: surfHeight{min: 1.2, max: 1.8}},
+		{text: "5ft", want: surfHeight{min: 1.524, max: 1.524}},
+		{text: "4-6FT", want: surfHeight{min: 1.2192, max: 1.8288}},
+		{text: "flat", wantErr: true},
+		{text: "1-2-3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := parseSurfHeight(tt.text, tt.format)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("got %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(got.min-tt.want.min) > 1e-9 || math.Abs(got.max-tt.want.max) > 1e-9 {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewForecast_Partial(t *testing.T) {
+	issuedAt := time.Date(2021, time.November, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		rows forecastRows
+		want bool
+	}{
+		{
+			name: "later than second day",
+			rows: forecastRows{
+				days:  []int{1, 2},
+				hours: [][]clockTime{{{hour: 9}}, {{hour: 0}, {hour: 9}}},
+			},
+			want: true,
+		},
+		{
+			name: "same as second day",
+			rows: forecastRows{
+				days:  []int{1, 2},
+				hours: [][]clockTime{{{hour: 0}, {hour: 9}}, {{hour: 0}, {hour: 9}}},
+			},
+		},
+		{
+			name: "later than next unscraped day",
+			rows: forecastRows{
+				days:      []int{1},
+				hours:     [][]clockTime{{{hour: 9}}},
+				nextHours: []clockTime{{hour: 0}, {hour: 9}},
+			},
+			want: true,
+		},
+		{
+			name: "single day without reference",
+			rows: forecastRows{
+				days:  []int{1},
+				hours: [][]clockTime{{{hour: 9}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newForecast(issuedAt, tt.rows)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := f.Daily[0].Partial; got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToTwentyFourClockHour(t *testing.T) {
+	tests := []struct {
+		hour   int
+		period clockPeriod
+		want   int
+	}{
+		{hour: 12, period: beforeMidday, want: 0},
+		{hour: 1, period: beforeMidday, want: 1},
+		{hour: 11, period: beforeMidday, want: 11},
+		{hour: 12, period: afterMidday, want: 12},
+		{hour: 1, period: afterMidday, want: 13},
+		{hour: 11, period: afterMidday, want: 23},
+	}
+
+	for _, tt := range tests {
+		if got := toTwentyFourClockHour(tt.hour, tt.period); got != tt.want {
+			t.Errorf("%d (period %d): got %d, want %d", tt.hour, tt.period, got, tt.want)
+		}
+	}
+}
+
+func TestScrapeHour(t *testing.T) {
+	tests := []struct {
+		name     string
+		cell     string
+		previous *clockTime
+		want     clockTime
+		wantErr  bool
+	}{
+		{
+			name: "12 AM",
+			cell: `<span class="forecast-table__value">12</span><span class="forecast-table__value">AM</span>`,
+			want: clockTime{hour: 0},
+		},
+		{
+			name: "12 PM",
+			cell: `<span class="forecast-table__value">12</span><span class="forecast-table__value">PM</span>`,
+			want: clockTime{hour: 12},
+		},
+		{
+			name: "1 AM",
+			cell: `<span class="forecast-table__value">AM</span><span class="forecast-table__value">1</span>`,
+			want: clockTime{hour: 1},
+		},
+		{
+			name: "11 PM with minutes",
+			cell: `<span class="forecast-table__value">11:30</span><span class="forecast-table__value">pm</span>`,
+			want: clockTime{hour: 23, minute: 30},
+		},
+		{
+			name: "missing period of first cell",
+			cell: `<span class="forecast-table__value">12</span>`,
+			want: clockTime{hour: 0},
+		},
+		{
+			name:     "missing period after morning hour",
+			cell:     `<span class="forecast-table__value">11</span>`,
+			previous: &clockTime{hour: 9},
+			want:     clockTime{hour: 11},
+		},
+		{
+			name:     "missing period of midday",
+			cell:     `<span class="forecast-table__value">12</span>`,
+			previous: &clockTime{hour: 9},
+			want:     clockTime{hour: 12},
+		},
+		{
+			name:     "missing period after afternoon hour",
+			cell:     `<span class="forecast-table__value">3</span>`,
+			previous: &clockTime{hour: 12},
+			want:     clockTime{hour: 15},
+		},
+		{
+			name:     "missing period wrapping past midday",
+			cell:     `<span class="forecast-table__value">3</span>`,
+			previous: &clockTime{hour: 9},
+			want:     clockTime{hour: 15},
+		},
+		{
+			name:     "missing period of midnight after afternoon hour",
+			cell:     `<span class="forecast-table__value">12</span>`,
+			previous: &clockTime{hour: 15},
+			wantErr:  true,
+		},
+		{
+			name:     "missing period of earlier afternoon hour",
+			cell:     `<span class="forecast-table__value">2</span>`,
+			previous: &clockTime{hour: 15},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := mustParseHTML(t, `<table><tr><td class="forecast-table__cell">`+tt.cell+`</td></tr></table>`)
+			cell, ok := htmlutil.FindOne(n, htmlutil.WithElement(atom.Td))
+			if !ok {
+				t.Fatal("could not find cell node")
+			}
+
+			got, err := scrapeHour(cell, tt.previous)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("got %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("missing hour", func(t *testing.T) {
+		n := mustParseHTML(t, `<table><tr><td><span class="forecast-table__value">PM</span></td></tr></table>`)
+		cell, _ := htmlutil.FindOne(n, htmlutil.WithElement(atom.Td))
+		if _, err := scrapeHour(cell, nil); err == nil {
+			t.Error("got nil error")
+		}
+	})
+}
+
+func TestScrapeWindState(t *testing.T) {
+	tests := []struct {
+		name    string
+		cell    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "single line",
+			cell: `<td>offshore</td>`,
+			want: "offshore",
+		},
+		{
+			name: "indented multiple lines",
+			cell: `<td>
+				<span>
+					cross
+				</span>
+				<span>
+					shore
+				</span>
+			</td>`,
+			want: "cross shore",
+		},
+		{
+			name: "tabs and newlines within text",
+			cell: "<td>\n\t\tglassy\n\t</td>",
+			want: "glassy",
+		},
+		{
+			name:    "whitespace only",
+			cell:    "<td>\n\t\t</td>",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := mustParseHTML(t, `<table><tr>`+tt.cell+`</tr></table>`)
+			cell, ok := htmlutil.FindOne(n, htmlutil.WithElement(atom.Td))
+			if !ok {
+				t.Fatal("could not find cell node")
+			}
+
+			got, err := scrapeWindState(cell)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("got %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScraper_ScrapeWindDirectionDegrees(t *testing.T) {
+	tests := []struct {
+		name    string
+		rotate  string
+		opts    []Option
+		want    float64
+		wantErr bool
+	}{
+		{name: "within range", rotate: "225", want: 225},
+		{name: "zero", rotate: "0", want: 0},
+		{name: "360 normalized", rotate: "360", want: 0},
+		{name: "above 360 rejected", rotate: "361", wantErr: true},
+		{name: "negative rejected", rotate: "-1", wantErr: true},
+		{name: "above 360 clamped", rotate: "361", opts: []Option{WithLenientParsing()}, want: 0},
+		{name: "negative clamped", rotate: "-1", opts: []Option{WithLenientParsing()}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := mustParseHTML(t, `<svg><g class="wind-icon__arrow" transform="rotate(`+tt.rotate+`)"></g></svg>`)
+
+			got, _, err := New(tt.opts...).scrapeWindDirectionDegrees(n)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("got %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScraper_MaxDays(t *testing.T) {
+	mustLoadLocation(t, "Asia/Makassar")
+
+	s := New(
+		WithBaseURL(newFixtureServer(t).URL),
+		WithMaxDays(1),
+	)
+
+	count, err := s.ForecastDayCount("Uluwatu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("day count: got %d, want 1", count)
+	}
+
+	winds, err := s.WindForecast("Uluwatu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(winds) != 1 || len(winds[0]) != 2 {
+		t.Errorf("winds: got %v, want 1 day of 2 hours", winds)
+	}
+
+	f, err := s.EightDaysForecast("Uluwatu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(f.Daily) != 1 {
+		t.Fatalf("days: got %d, want 1", len(f.Daily))
+	}
+	if !f.Daily[0].Partial {
+		t.Error("first day is not partial")
+	}
+}
+
+func TestNewForecast_Rollover(t *testing.T) {
+	tests := []struct {
+		name     string
+		issuedAt time.Time
+		days     []int
+		want     []time.Time
+	}{
+		{
+			name:     "same month",
+			issuedAt: time.Date(2021, time.October, 10, 4, 0, 0, 0, time.UTC),
+			days:     []int{10, 11},
+			want: []time.Time{
+				time.Date(2021, time.October, 10, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, time.October, 11, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "month rollover",
+			issuedAt: time.Date(2021, time.October, 30, 4, 0, 0, 0, time.UTC),
+			days:     []int{30, 31, 1, 2},
+			want: []time.Time{
+				time.Date(2021, time.October, 30, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, time.October, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, time.November, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, time.November, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "year rollover",
+			issuedAt: time.Date(2021, time.December, 30, 4, 0, 0, 0, time.UTC),
+			days:     []int{30, 31, 1},
+			want: []time.Time{
+				time.Date(2021, time.December, 30, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, time.December, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "february of leap year",
+			issuedAt: time.Date(2024, time.February, 28, 4, 0, 0, 0, time.UTC),
+			days:     []int{28, 29, 1},
+			want: []time.Time{
+				time.Date(2024, time.February, 28, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newForecast(tt.issuedAt, forecastRows{
+				days:  tt.days,
+				hours: make([][]clockTime, len(tt.days)),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(f.Daily) != len(tt.want) {
+				t.Fatalf("got %d days, want %d", len(f.Daily), len(tt.want))
+			}
+			for i, d := range f.Daily {
+				if !d.Timestamp.Equal(tt.want[i]) {
+					t.Errorf("day %d: got %v, want %v", i, d.Timestamp, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestForecast_Flatten(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2021, time.December, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	f := &Forecast{Daily: []*DailyForecast{
+		{Hourly: []HourlyForecast{{Timestamp: at(31, 12)}, {Timestamp: at(31, 6)}}},
+		nil,
+		{Hourly: []HourlyForecast{{Timestamp: at(30, 18)}}},
+	}}
+
+	got := f.Flatten()
+
+	want := []time.Time{at(30, 18), at(31, 6), at(31, 12)}
+	if len(got) != len(want) {
+		t.Fatalf("got %d hours, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].Timestamp.Equal(want[i]) {
+			t.Errorf("hour %d: got %v, want %v", i, got[i].Timestamp, want[i])
+		}
+	}
+}
+
+func TestForecast_Between(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2021, time.December, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	f := &Forecast{Daily: []*DailyForecast{
+		{Hourly: []HourlyForecast{{Timestamp: at(31, 12)}, {Timestamp: at(31, 6)}}},
+		nil,
+		{Hourly: []HourlyForecast{{Timestamp: at(30, 18)}, {Timestamp: at(30, 12)}}},
+	}}
+
+	got := f.Between(at(30, 18), at(31, 6))
+
+	want := []time.Time{at(30, 18), at(31, 6)}
+	if len(got) != len(want) {
+		t.Fatalf("got %d hours, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].Timestamp.Equal(want[i]) {
+			t.Errorf("hour %d: got %v, want %v", i, got[i].Timestamp, want[i])
+		}
+	}
+
+	if got := f.Between(at(31, 6), at(30, 18)); len(got) != 0 {
+		t.Errorf("got %d hours for an inverted window, want 0", len(got))
+	}
+}
+
+func TestForecast_ByTimestamp(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2021, time.December, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	f := &Forecast{Daily: []*DailyForecast{
+		{Hourly: []HourlyForecast{{Timestamp: at(30, 18), Rating: 3}}},
+		nil,
+		{Hourly: []HourlyForecast{{Timestamp: at(31, 6), Rating: 5}}},
+	}}
+
+	got := f.ByTimestamp()
+
+	if len(got) != 2 {
+		t.Fatalf("got %d hours, want 2", len(got))
+	}
+	if h, ok := got[at(31, 6)]; !ok || h.Rating != 5 {
+		t.Errorf("got %+v, want rating 5", h)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
 const (
@@ -18,6 +19,12 @@ const (
 
 	// AttributeTransform is the key of the transform attribute.
 	AttributeTransform = "transform"
+
+	// AttributeSource is the key of the src attribute.
+	AttributeSource = "src"
+
+	// AttributeTitle is the key of the title attribute.
+	AttributeTitle = "title"
 )
 
 // Find walks through the given node and all its childen and returns those that
@@ -98,6 +105,14 @@ func WithAttributeEqual(key, value string) FindCondition {
 	}
 }
 
+// WithElement returns FindCondition that checks if a node is an element of the
+// given type.
+func WithElement(a atom.Atom) FindCondition {
+	return func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.DataAtom == a
+	}
+}
+
 // WithAttribute returns FindCondition that checks if a node has the given attribute.
 func WithAttribute(key string) FindCondition {
 	return func(n *html.Node) bool {
@@ -121,6 +136,23 @@ func ClassContains(n *html.Node, values ...string) bool {
 	return true
 }
 
+// HasClass checks if the given node's class attribute holds the given value as one
+// of its whitespace-separated class names. Unlike ClassContains, it does not match
+// class names that merely contain the value, such as "break-header__photo" for
+// "break-header".
+func HasClass(n *html.Node, value string) bool {
+	attr, ok := Attribute(n, AttributeClass)
+	if !ok {
+		return false
+	}
+	for _, class := range strings.Fields(attr.Val) {
+		if class == value {
+			return true
+		}
+	}
+	return false
+}
+
 // IDEquals checks if the given node's id attribute equals to the given value.
 func IDEquals(n *html.Node, value string) bool {
 	return AttributeEquals(n, AttributeID, value)
@@ -175,3 +207,25 @@ func ForEach(n *html.Node, statement ForEachStatement) error {
 // executed for each iteration of ForEach loop. When a non-nil error is returned,
 // the early termination of the loop gets triggered.
 type ForEachStatement func(*html.Node) error
+
+// FirstElementChild returns the first child of the given node that is an element,
+// skipping text nodes such as whitespace between elements.
+func FirstElementChild(n *html.Node) (*html.Node, bool) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// NextElementSibling returns the next sibling of the given node that is an element,
+// skipping text nodes such as whitespace between elements.
+func NextElementSibling(n *html.Node) (*html.Node, bool) {
+	for s := n.NextSibling; s != nil; s = s.NextSibling {
+		if s.Type == html.ElementNode {
+			return s, true
+		}
+	}
+	return nil, false
+}
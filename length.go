@@ -0,0 +1,38 @@
+package surfforecast
+
+// metersPerFoot is the number of meters in an international foot.
+const metersPerFoot = 0.3048
+
+// Length is a length stored in meters. It provides type safety for the heights
+// that the forecast types otherwise hold as plain floats in meters.
+type Length float64
+
+// Meters returns the length in meters.
+func (l Length) Meters() float64 {
+	return float64(l)
+}
+
+// Feet returns the length in feet.
+func (l Length) Feet() float64 {
+	return float64(l) / metersPerFoot
+}
+
+// WaveHeight returns WaveHeightInMeters of the swell as Length.
+func (s Swell) WaveHeight() Length {
+	return Length(s.WaveHeightInMeters)
+}
+
+// SurfHeightMin returns SurfHeightMinInMeters of the hourly forecast as Length.
+func (h HourlyForecast) SurfHeightMin() Length {
+	return Length(h.SurfHeightMinInMeters)
+}
+
+// SurfHeightMax returns SurfHeightMaxInMeters of the hourly forecast as Length.
+func (h HourlyForecast) SurfHeightMax() Length {
+	return Length(h.SurfHeightMaxInMeters)
+}
+
+// Height returns HeightInMeters of the tide extreme as Length.
+func (t Tide) Height() Length {
+	return Length(t.HeightInMeters)
+}
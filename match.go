@@ -0,0 +1,97 @@
+package surfforecast
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Weights of the conditions that MatchScore compares.
+const (
+	matchWeightSwellDirection = 0.4
+	matchWeightWindDirection  = 0.3
+	matchWeightSwellSize      = 0.3
+)
+
+// MatchScore scores how well the given hourly forecast matches the ideal conditions
+// of the given surf break, from 0 for the worst match to 1 for the best one. It is
+// a weighted average of the following conditions:
+//
+//   - 40% for the primary swell's direction compared to Break.BestSwellDirection.
+//   - 30% for the wind's direction compared to Break.BestWindDirection.
+//   - 30% for the primary swell's wave height compared to Break.IdealSwellSize.
+//
+// A direction scores 1 when it matches the ideal one and falls linearly to 0 when
+// it is opposite to it. A wave height scores 1 when it matches the ideal size and
+// falls linearly to 0 when it is off by the ideal size or more, so that a flat sea
+// and twice the ideal size both score 0.
+//
+// Conditions that the surf break does not specify, or specifies in a format that
+// cannot be parsed, are left out and the weights of the rest are scaled up
+// accordingly. 0 is returned when none of the conditions can be compared. The best
+// tide is not compared since hourly forecasts hold no tide state.
+func (h HourlyForecast) MatchScore(b Break) float64 {
+	var score, weights float64
+
+	if best, ok := CompassPointToDegrees(b.BestSwellDirection); ok {
+		from := math.Mod(h.Swells.Primary.DirectionToInDegrees+180, 360)
+		score += matchWeightSwellDirection * directionScore(from, best)
+		weights += matchWeightSwellDirection
+	}
+
+	if best, ok := CompassPointToDegrees(b.BestWindDirection); ok {
+		from := math.Mod(h.Wind.DirectionToInDegrees+180, 360)
+		score += matchWeightWindDirection * directionScore(from, best)
+		weights += matchWeightWindDirection
+	}
+
+	if ideal, ok := parseSwellSize(b.IdealSwellSize); ok && ideal > 0 {
+		diff := math.Abs(h.Swells.Primary.WaveHeightInMeters - ideal)
+		score += matchWeightSwellSize * math.Max(0, 1-diff/ideal)
+		weights += matchWeightSwellSize
+	}
+
+	if weights == 0 {
+		return 0
+	}
+
+	return score / weights
+}
+
+// directionScore scores how close both directions in degrees are, from 1 for the
+// same direction to 0 for the opposite ones.
+func directionScore(a, b float64) float64 {
+	return 1 - angleBetween(a, b)/180
+}
+
+// parseSwellSize parses a swell size of the surf break's details panel in meters,
+// for instance "1.5m", "5ft" or "1-2m". The middle of a range is returned. Sizes
+// without a unit are treated as meters.
+func parseSwellSize(s string) (float64, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	factor := 1.0
+	switch {
+	case strings.HasSuffix(s, "ft"):
+		s, factor = strings.TrimSuffix(s, "ft"), metersPerFoot
+	case strings.HasSuffix(s, "m"):
+		s = strings.TrimSuffix(s, "m")
+	}
+
+	var (
+		parts = strings.Split(s, "-")
+		sum   float64
+	)
+	if len(parts) > 2 {
+		return 0, false
+	}
+	for _, p := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return 0, false
+		}
+		sum += v
+	}
+
+	return sum / float64(len(parts)) * factor, true
+}
@@ -0,0 +1,89 @@
+package surfforecast
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHourlyForecast_MatchScore(t *testing.T) {
+	// hour returns an hourly forecast whose primary swell and wind come from the
+	// given directions in degrees.
+	hour := func(swellFrom, windFrom, height float64) HourlyForecast {
+		var h HourlyForecast
+		h.Swells.Primary = Swell{
+			DirectionToInDegrees: math.Mod(swellFrom+180, 360),
+			WaveHeightInMeters:   height,
+		}
+		h.Wind = Wind{
+			SpeedInKilometersPerHour: 10,
+			DirectionToInDegrees:     math.Mod(windFrom+180, 360),
+		}
+		return h
+	}
+
+	ideal := Break{
+		BestSwellDirection: "SW",
+		BestWindDirection:  "E",
+		IdealSwellSize:     "1-2m",
+	}
+
+	tests := []struct {
+		name string
+		hour HourlyForecast
+		brk  Break
+		want float64
+	}{
+		{name: "perfect", hour: hour(225, 90, 1.5), brk: ideal, want: 1},
+		{name: "opposite directions", hour: hour(45, 270, 1.5), brk: ideal, want: 0.3},
+		{name: "flat", hour: hour(225, 90, 0), brk: ideal, want: 0.7},
+		{name: "twice the size", hour: hour(225, 90, 3), brk: ideal, want: 0.7},
+		{name: "half off size", hour: hour(225, 90, 2.25), brk: ideal, want: 0.85},
+		{name: "swell direction off by 90", hour: hour(315, 90, 1.5), brk: ideal, want: 0.8},
+		{
+			name: "size in feet",
+			hour: hour(225, 90, 5*metersPerFoot),
+			brk:  Break{BestSwellDirection: "SW", BestWindDirection: "E", IdealSwellSize: "5ft"},
+			want: 1,
+		},
+		{
+			name: "only wind known",
+			hour: hour(225, 180, 1.5),
+			brk:  Break{BestSwellDirection: "unknown", BestWindDirection: "E", IdealSwellSize: "big"},
+			want: 0.5,
+		},
+		{name: "nothing known", hour: hour(225, 90, 1.5), brk: Break{}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.hour.MatchScore(tt.brk)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSwellSize(t *testing.T) {
+	tests := []struct {
+		text   string
+		want   float64
+		wantOK bool
+	}{
+		{text: "1.5m", want: 1.5, wantOK: true},
+		{text: "1-2m", want: 1.5, wantOK: true},
+		{text: " 2 ", want: 2, wantOK: true},
+		{text: "5ft", want: 5 * metersPerFoot, wantOK: true},
+		{text: "4-6FT", want: 5 * metersPerFoot, wantOK: true},
+		{text: "1-2-3m"},
+		{text: "unknown"},
+		{text: ""},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseSwellSize(tt.text)
+		if ok != tt.wantOK || math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%q: got %v %v, want %v %v", tt.text, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
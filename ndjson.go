@@ -0,0 +1,97 @@
+package surfforecast
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ndjsonHour is a record of an hourly forecast written by WriteNDJSON.
+type ndjsonHour struct {
+	Break                  string        `json:"break"`
+	IssuedAt               time.Time     `json:"issued_at"`
+	Timestamp              time.Time     `json:"timestamp"`
+	Partial                bool          `json:"partial"`
+	Confidence             string        `json:"confidence"`
+	Rating                 int           `json:"rating"`
+	RatingBand             string        `json:"rating_band"`
+	SurfHeightMinInMeters  float64       `json:"surf_height_min_m"`
+	SurfHeightMaxInMeters  float64       `json:"surf_height_max_m"`
+	WaveEnergyInKiloJoules float64       `json:"wave_energy_kj"`
+	PrimarySwell           ndjsonSwell   `json:"primary_swell"`
+	SecondarySwells        []ndjsonSwell `json:"secondary_swells"`
+	WindSpeed              float64       `json:"wind_speed_kmh"`
+	WindDirectionTo        float64       `json:"wind_direction_to_deg"`
+	WindDirectionFrom      string        `json:"wind_direction_from"`
+	WindState              string        `json:"wind_state"`
+}
+
+// ndjsonSwell is a record of a swell written by WriteNDJSON.
+type ndjsonSwell struct {
+	PeriodInSeconds    float64 `json:"period_s"`
+	DirectionTo        float64 `json:"direction_to_deg"`
+	DirectionFrom      string  `json:"direction_from"`
+	WaveHeightInMeters float64 `json:"wave_height_m"`
+}
+
+func newNDJSONSwell(s Swell) ndjsonSwell {
+	return ndjsonSwell{
+		PeriodInSeconds:    s.PeriodInSeconds,
+		DirectionTo:        s.DirectionToInDegrees,
+		DirectionFrom:      s.DirectionFromInCompassPoints,
+		WaveHeightInMeters: s.WaveHeightInMeters,
+	}
+}
+
+// WriteNDJSON writes the hourly forecasts of the given surf break's forecast to the
+// given writer as newline-delimited JSON, one flat object per hourly forecast. Each
+// object carries the surf break's name, the forecast's issue timestamp and its
+// day's details, so the objects of many surf breaks can be appended to the same
+// stream and loaded as a single table. The objects are written one by one in
+// chronological order, and days that are nil are skipped.
+func WriteNDJSON(w io.Writer, breakName string, f *Forecast) error {
+	enc := json.NewEncoder(w)
+
+	days := make(map[time.Time]*DailyForecast)
+	for _, d := range f.Daily {
+		if d == nil {
+			continue
+		}
+		for _, h := range d.Hourly {
+			days[h.Timestamp] = d
+		}
+	}
+
+	for _, h := range f.Flatten() {
+		d := days[h.Timestamp]
+
+		secondary := make([]ndjsonSwell, len(h.Swells.Secondary))
+		for i, s := range h.Swells.Secondary {
+			secondary[i] = newNDJSONSwell(s)
+		}
+
+		if err := enc.Encode(ndjsonHour{
+			Break:                  breakName,
+			IssuedAt:               f.IssuedAt,
+			Timestamp:              h.Timestamp,
+			Partial:                d.Partial,
+			Confidence:             d.Confidence.String(),
+			Rating:                 h.Rating,
+			RatingBand:             h.RatingBand.String(),
+			SurfHeightMinInMeters:  h.SurfHeightMinInMeters,
+			SurfHeightMaxInMeters:  h.SurfHeightMaxInMeters,
+			WaveEnergyInKiloJoules: h.WaveEnergyInKiloJoules,
+			PrimarySwell:           newNDJSONSwell(h.Swells.Primary),
+			SecondarySwells:        secondary,
+			WindSpeed:              h.Wind.SpeedInKilometersPerHour,
+			WindDirectionTo:        h.Wind.DirectionToInDegrees,
+			WindDirectionFrom:      h.Wind.DirectionFromInCompassPoints,
+			WindState:              h.Wind.State,
+		}); err != nil {
+			return fmt.Errorf("could not write hourly forecast of %s: %w", h.Timestamp, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package surfforecast
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestWriteNDJSON(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2021, time.December, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	f := &Forecast{Daily: []*DailyForecast{
+		{Confidence: ConfidenceHigh, Hourly: []HourlyForecast{{Timestamp: at(31, 6)}}},
+		nil,
+		{Partial: true, Hourly: []HourlyForecast{{Timestamp: at(30, 18)}}},
+	}}
+
+	var buf bytes.Buffer
+	if err := WriteNDJSON(&buf, "Uluwatu", f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []ndjsonHour
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var h ndjsonHour
+		if err := json.Unmarshal(scanner.Bytes(), &h); err != nil {
+			t.Fatalf("could not decode line: %v", err)
+		}
+		got = append(got, h)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("got %d lines, want 2", len(got))
+	}
+	if !got[0].Timestamp.Equal(at(30, 18)) || !got[0].Partial {
+		t.Errorf("line 0: got %+v, want partial hour at %v", got[0], at(30, 18))
+	}
+	if !got[1].Timestamp.Equal(at(31, 6)) || got[1].Confidence != ConfidenceHigh.String() {
+		t.Errorf("line 1: got %+v, want hour at %v with %s confidence", got[1], at(31, 6), ConfidenceHigh)
+	}
+}
@@ -0,0 +1,34 @@
+package surfforecast
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NumberFormat is a format of decimal numbers displayed by www.surf-forecast.com.
+type NumberFormat int
+
+const (
+	// NumberFormatDot is the format that uses a dot as the decimal separator, for
+	// instance "1.5". It is used by the English pages of the site.
+	NumberFormatDot NumberFormat = iota
+	// NumberFormatComma is the format that uses a comma as the decimal separator,
+	// for instance "1,5". It is used by some localized pages of the site.
+	NumberFormatComma
+)
+
+// parseFloat parses the given decimal number written in the number format.
+func (f NumberFormat) parseFloat(s string) (float64, error) {
+	text := strings.TrimSpace(s)
+	if f == NumberFormatComma {
+		text = strings.Replace(text, ",", ".", 1)
+	}
+
+	v, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, fmt.Errorf("not float: %q", s)
+	}
+
+	return v, nil
+}
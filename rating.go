@@ -0,0 +1,184 @@
+package surfforecast
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/ztimes2/surfforecast-go/internal/htmlutil"
+	"golang.org/x/net/html"
+)
+
+const (
+	// groundswellMinPeriodInSeconds is the minimum swell period for a swell to
+	// be considered a groundswell by DefaultRatingFunc.
+	groundswellMinPeriodInSeconds = 12
+
+	// windswellMaxPeriodInSeconds is the maximum swell period for a swell to be
+	// considered a windswell by DefaultRatingFunc.
+	windswellMaxPeriodInSeconds = 8
+
+	minRating = 0
+	maxRating = 10
+)
+
+// RatingFunc is a function that computes a custom rating score of an hourly
+// forecast from its raw components.
+type RatingFunc func(HourlyForecast) float64
+
+// AdjustedRating computes a rating score of the given hourly forecast using the
+// given function. DefaultRatingFunc is used when the function is nil.
+func (h HourlyForecast) AdjustedRating(fn RatingFunc) float64 {
+	if fn == nil {
+		fn = DefaultRatingFunc
+	}
+	return fn(h)
+}
+
+// AdjustedRating computes a rating score of the given hourly forecast using the
+// function set with WithRatingFunc, or DefaultRatingFunc if none was set.
+func (s *Scraper) AdjustedRating(h HourlyForecast) float64 {
+	return h.AdjustedRating(s.ratingFunc)
+}
+
+// DefaultRatingFunc adjusts the rating score of www.surf-forecast.com which tends
+// to overrate short-period windswell. Starting from the site's rating, it:
+//   - adds 1 point when the primary swell's period is at least 12 seconds (groundswell),
+//   - subtracts 1 point when the primary swell's period is below 8 seconds (windswell),
+//   - subtracts 2 points when the wind is onshore,
+//   - subtracts 1 point when the wind is cross-onshore.
+//
+// The result is clamped to the range from 0 to 10. Use a custom RatingFunc with
+// HourlyForecast.AdjustedRating or WithRatingFunc in order to apply different
+// weights.
+func DefaultRatingFunc(h HourlyForecast) float64 {
+	rating := float64(h.Rating)
+
+	switch period := h.Swells.Primary.PeriodInSeconds; {
+	case period >= groundswellMinPeriodInSeconds:
+		rating++
+	case period < windswellMaxPeriodInSeconds:
+		rating--
+	}
+
+	state, _ := ParseWindState(h.Wind.State)
+	switch state {
+	case WindStateCrossOnshore:
+		rating--
+	case WindStateOnshore:
+		rating -= 2
+	}
+
+	if rating < minRating {
+		return minRating
+	}
+	if rating > maxRating {
+		return maxRating
+	}
+	return rating
+}
+
+// LowestRatedHours returns the hourly forecasts of all days whose rating is equal
+// to or below the given threshold, in chronological order. It can be used, for
+// instance, for warning about the hours with poor or dangerous conditions. Days
+// that are nil are skipped.
+func (f *Forecast) LowestRatedHours(threshold int) []HourlyForecast {
+	var forecasts []HourlyForecast
+	for _, h := range f.Flatten() {
+		if h.Rating <= threshold {
+			forecasts = append(forecasts, h)
+		}
+	}
+	return forecasts
+}
+
+// BestDay returns the day with the highest average rating of its hourly forecasts,
+// so that a day with many good hours wins over a day with a single outstanding
+// one. Ties are broken by the higher average wave energy, and the earlier day wins
+// when both are equal. Days that are nil or without hourly forecasts are skipped,
+// and false is returned when none of the days has any.
+func (f *Forecast) BestDay() (*DailyForecast, bool) {
+	var (
+		best                   *DailyForecast
+		bestRating, bestEnergy float64
+	)
+	for _, d := range f.Daily {
+		if d == nil || len(d.Hourly) == 0 {
+			continue
+		}
+
+		var rating, energy float64
+		for _, h := range d.Hourly {
+			rating += float64(h.Rating)
+			energy += h.WaveEnergyInKiloJoules
+		}
+		rating /= float64(len(d.Hourly))
+		energy /= float64(len(d.Hourly))
+
+		if best == nil || rating > bestRating || (rating == bestRating && energy > bestEnergy) {
+			best, bestRating, bestEnergy = d, rating, energy
+		}
+	}
+	return best, best != nil
+}
+
+// RatingBand is a quality band that www.surf-forecast.com conveys by coloring the
+// rating cells of the forecast table.
+type RatingBand int
+
+const (
+	// RatingBandUnknown indicates that the band could not be determined from the
+	// rating cell.
+	RatingBandUnknown RatingBand = iota
+	// RatingBandPoor is the band of poor conditions that is colored red.
+	RatingBandPoor
+	// RatingBandFair is the band of fair conditions that is colored amber.
+	RatingBandFair
+	// RatingBandGood is the band of good conditions that is colored green.
+	RatingBandGood
+)
+
+// ratingBandColors maps the colors that might appear in the classes of a rating
+// cell to RatingBand.
+var ratingBandColors = map[string]RatingBand{
+	"red":    RatingBandPoor,
+	"amber":  RatingBandFair,
+	"orange": RatingBandFair,
+	"yellow": RatingBandFair,
+	"green":  RatingBandGood,
+}
+
+// String returns a human-readable name of the rating band.
+func (b RatingBand) String() string {
+	switch b {
+	case RatingBandPoor:
+		return "poor"
+	case RatingBandFair:
+		return "fair"
+	case RatingBandGood:
+		return "good"
+	default:
+		return "unknown"
+	}
+}
+
+// scrapeRatingBand determines the rating band of the given rating cell by looking
+// for a color among the words of its classes and the classes of its rating node,
+// for instance "rating--green". RatingBandUnknown is returned when none is found.
+func scrapeRatingBand(nodes ...*html.Node) RatingBand {
+	for _, n := range nodes {
+		attr, ok := htmlutil.Attribute(n, htmlutil.AttributeClass)
+		if !ok {
+			continue
+		}
+
+		words := strings.FieldsFunc(strings.ToLower(attr.Val), func(r rune) bool {
+			return !unicode.IsLetter(r)
+		})
+		for _, w := range words {
+			if band, ok := ratingBandColors[w]; ok {
+				return band
+			}
+		}
+	}
+	return RatingBandUnknown
+}
@@ -0,0 +1,73 @@
+package surfforecast
+
+import (
+	"testing"
+	"time"
+)
+
+func TestForecast_LowestRatedHours(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2021, time.December, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	f := &Forecast{Daily: []*DailyForecast{
+		{Hourly: []HourlyForecast{{Timestamp: at(31, 12), Rating: 1}, {Timestamp: at(31, 6), Rating: 5}}},
+		nil,
+		{Hourly: []HourlyForecast{{Timestamp: at(30, 18), Rating: 2}}},
+	}}
+
+	got := f.LowestRatedHours(2)
+
+	want := []time.Time{at(30, 18), at(31, 12)}
+	if len(got) != len(want) {
+		t.Fatalf("got %d hours, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].Timestamp.Equal(want[i]) {
+			t.Errorf("hour %d: got %v, want %v", i, got[i].Timestamp, want[i])
+		}
+	}
+}
+
+func TestForecast_BestDay(t *testing.T) {
+	day := func(day int) time.Time {
+		return time.Date(2021, time.December, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	f := &Forecast{Daily: []*DailyForecast{
+		nil,
+		{Timestamp: day(1), Hourly: []HourlyForecast{{Rating: 2}, {Rating: 4}}},
+		{Timestamp: day(2)},
+		{Timestamp: day(3), Hourly: []HourlyForecast{{Rating: 3, WaveEnergyInKiloJoules: 500}}},
+	}}
+
+	got, ok := f.BestDay()
+	if !ok {
+		t.Fatal("got no day")
+	}
+	if !got.Timestamp.Equal(day(3)) {
+		t.Errorf("got %v, want %v", got.Timestamp, day(3))
+	}
+
+	if _, ok := (&Forecast{Daily: []*DailyForecast{nil, {Timestamp: day(1)}}}).BestDay(); ok {
+		t.Error("got a day, want none")
+	}
+}
+
+func TestScraper_AdjustedRating(t *testing.T) {
+	h := HourlyForecast{Rating: 5, Swells: Swells{Primary: Swell{PeriodInSeconds: 14}}}
+
+	if got := New().AdjustedRating(h); got != 6 {
+		t.Errorf("default: got %v, want 6", got)
+	}
+
+	s := New(WithRatingFunc(func(h HourlyForecast) float64 {
+		return float64(h.Rating) / 2
+	}))
+	if got := s.AdjustedRating(h); got != 2.5 {
+		t.Errorf("custom: got %v, want 2.5", got)
+	}
+	if got := s.Derived(h).AdjustedRating; got != 2.5 {
+		t.Errorf("custom derived: got %v, want 2.5", got)
+	}
+}
@@ -1,26 +1,105 @@
 package surfforecast
 
 import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
 	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/tkuchiki/go-timezone"
+	"golang.org/x/net/html"
 )
 
+// Version is the version of the package.
+const Version = "0.1.0"
+
 const (
 	baseURL = "https://www.surf-forecast.com"
 )
 
 const (
 	defaultRequestTimeout = 10 * time.Second
+	defaultAcceptLanguage = "en"
+	defaultUserAgent      = "surfforecast-go/" + Version
+)
+
+const (
+	headerAcceptLanguage  = "Accept-Language"
+	headerContentEncoding = "Content-Encoding"
+	headerUserAgent       = "User-Agent"
 )
 
+// Fetcher fetches pages of www.surf-forecast.com for Scraper, for instance using
+// a headless browser instead of plain HTTP requests.
+type Fetcher interface {
+	// Fetch fetches the page at the given path relative to the website's root
+	// and parses it as HTML. ErrBreakNotFound must be returned when the page does
+	// not exist.
+	Fetch(ctx context.Context, path string) (*html.Node, error)
+}
+
+// FetcherFunc is an adapter that allows using an ordinary function as Fetcher.
+type FetcherFunc func(ctx context.Context, path string) (*html.Node, error)
+
+// Fetch calls fn(ctx, path).
+func (fn FetcherFunc) Fetch(ctx context.Context, path string) (*html.Node, error) {
+	return fn(ctx, path)
+}
+
 // Scraper is a web scraper that sends requests to www.surf-forecast.com and scrapes
 // data from its responses.
+//
+// All methods of Scraper only send GET requests without bodies, and they build a
+// new request on every call, so it is safe to retry them.
+//
+// Methods that accept a context send requests bound to it, so a request is
+// aborted either when the timeout of the HTTP client elapses or when the context's
+// deadline is exceeded, whichever comes first. The timeout can be disabled using
+// WithTimeout(0), so that only the context governs.
+//
+// Scraper is safe for concurrent use by multiple goroutines, so a single instance
+// is meant to be shared, for instance by a pool of workers. Its configuration is
+// never modified after New returns, and the HTTP client, the timezones and the
+// logger it holds are safe for concurrent use themselves. Custom functions and
+// values set using the options, for instance a request decorator, a Fetcher or a
+// clock, are called concurrently as well, so they must be safe for concurrent use
+// too.
 type Scraper struct {
-	httpClient *http.Client
-	timezones  *timezone.Timezone
-	baseURL    string
+	httpClient            *http.Client
+	timezones             *timezone.Timezone
+	parseHTML             func(io.Reader) (*html.Node, error)
+	now                   func() time.Time
+	acceptLanguage        string
+	decorate              func(*http.Request) error
+	fallbackOffsets       map[string]int
+	forecastTable         ForecastTable
+	logger                *log.Logger
+	lenientParsing        bool
+	fetcher               Fetcher
+	forecastLocation      *time.Location
+	consistencyChecks     bool
+	rawValues             bool
+	strictScraping        bool
+	timezoneAbbreviations map[string]string
+	consentCookie         *http.Cookie
+	maxDays               int
+	numberFormat          NumberFormat
+	recordDir             string
+	baseURL               string
+	exposeResponseHeaders bool
+	issueDateTolerance    time.Duration
+	issueDateValidation   ValidationMode
+	maxSwellsPerHour      int
+	ratingFunc            RatingFunc
 }
 
 // New initializes a new Scraper.
@@ -31,10 +110,223 @@ func New(opts ...Option) *Scraper {
 	}
 
 	return &Scraper{
-		httpClient: o.resolveHTTPClient(),
-		timezones:  o.resolveTimezones(),
-		baseURL:    baseURL,
+		httpClient:            o.resolveHTTPClient(),
+		timezones:             o.resolveTimezones(),
+		parseHTML:             o.resolveHTMLParser(),
+		now:                   o.resolveClock(),
+		acceptLanguage:        o.resolveAcceptLanguage(),
+		decorate:              o.decorateRequest,
+		fallbackOffsets:       o.resolveFallbackOffsets(),
+		forecastTable:         o.forecastTable,
+		logger:                o.resolveLogger(),
+		lenientParsing:        o.lenientParsing,
+		fetcher:               o.fetcher,
+		forecastLocation:      o.forecastLocation,
+		consistencyChecks:     o.consistencyChecks,
+		rawValues:             o.rawValues,
+		strictScraping:        o.strictScraping,
+		timezoneAbbreviations: o.timezoneAbbreviations,
+		consentCookie:         o.consentCookie,
+		maxDays:               o.maxDays,
+		numberFormat:          o.numberFormat,
+		recordDir:             o.recordDir,
+		baseURL:               o.resolveBaseURL(),
+		exposeResponseHeaders: o.exposeResponseHeaders,
+		issueDateTolerance:    o.issueDateTolerance,
+		issueDateValidation:   o.issueDateValidation,
+		maxSwellsPerHour:      o.maxSwellsPerHour,
+		ratingFunc:            o.ratingFunc,
+	}
+}
+
+// newRequest prepares a GET request to the given URL with the headers that are
+// common for all requests sent by Scraper. The User-Agent header identifies the
+// package and its version, and it can be overridden using a request decorator.
+func (s *Scraper) newRequest(ctx context.Context, url string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set(headerAcceptLanguage, s.acceptLanguage)
+	req.Header.Set(headerUserAgent, defaultUserAgent)
+
+	if s.consentCookie != nil {
+		req.AddCookie(s.consentCookie)
+	}
+
+	return req, nil
+}
+
+// do decorates the given request in case if a request decorator was provided, and
+// sends it.
+func (s *Scraper) do(req *http.Request) (*http.Response, error) {
+	if s.decorate != nil {
+		if err := s.decorate(req); err != nil {
+			return nil, fmt.Errorf("could not decorate request: %w", err)
+		}
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not send request: %w", err)
+	}
+
+	return resp, nil
+}
+
+// breaksPathPrefix returns the path prefix of the surf break pages, which includes
+// the path of a custom base URL, if any.
+func (s *Scraper) breaksPathPrefix() string {
+	u, err := url.Parse(s.baseURL)
+	if err != nil {
+		return pathPrefixBreaks
+	}
+	return strings.TrimSuffix(u.Path, "/") + pathPrefixBreaks
+}
+
+// fetch sends a request to the given path and returns the response's body along
+// with its headers. Failures are returned as ScrapeError.
+//
+// ErrBreakNotFound is returned when the requested page does not exist, and
+// ErrBreakHasNoForecast is returned when a surf break's page redirects outside of
+// the surf break pages.
+func (s *Scraper) fetch(ctx context.Context, path string) ([]byte, http.Header, error) {
+	req, err := s.newRequest(ctx, s.baseURL+path)
+	if err != nil {
+		return nil, nil, &ScrapeError{
+			Endpoint: path,
+			Stage:    StageFetch,
+			Err:      fmt.Errorf("could not prepare request: %w", err),
+		}
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, nil, &ScrapeError{Endpoint: path, Stage: StageFetch, Err: err}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+
+		err := ErrBreakNotFound
+		if resp.StatusCode != http.StatusNotFound {
+			err = fmt.Errorf("received response with %d status code", resp.StatusCode)
+		}
+		return nil, nil, &ScrapeError{
+			Endpoint:   path,
+			StatusCode: resp.StatusCode,
+			Stage:      StageFetch,
+			Err:        err,
+		}
+	}
+
+	if strings.HasPrefix(path, pathPrefixBreaks) &&
+		!strings.HasPrefix(resp.Request.URL.Path, s.breaksPathPrefix()) {
+		resp.Body.Close()
+		return nil, nil, &ScrapeError{
+			Endpoint:   path,
+			StatusCode: resp.StatusCode,
+			Stage:      StageFetch,
+			Err:        ErrBreakHasNoForecast,
+		}
+	}
+
+	defer resp.Body.Close()
+
+	r, err := decompress(resp)
+	if err != nil {
+		return nil, nil, &ScrapeError{
+			Endpoint:   path,
+			StatusCode: resp.StatusCode,
+			Stage:      StageFetch,
+			Err:        fmt.Errorf("could not decompress response body: %w", err),
+		}
+	}
+
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, nil, &ScrapeError{
+			Endpoint:   path,
+			StatusCode: resp.StatusCode,
+			Stage:      StageFetch,
+			Err:        fmt.Errorf("could not read response body: %w", err),
+		}
+	}
+
+	if s.recordDir != "" {
+		if err := s.record(path, body); err != nil {
+			return nil, nil, &ScrapeError{
+				Endpoint:   path,
+				StatusCode: resp.StatusCode,
+				Stage:      StageFetch,
+				Err:        fmt.Errorf("could not record response body: %w", err),
+			}
+		}
+	}
+
+	return body, resp.Header, nil
+}
+
+// record writes the given response body of the given path to a file in the record
+// directory. The file is named after the escaped path, so that each path maps to
+// its own file, for instance "breaks%2FUluwatu%2Fforecasts%2Flatest.html".
+func (s *Scraper) record(path string, body []byte) error {
+	name := url.QueryEscape(strings.TrimPrefix(path, "/")) + ".html"
+	return ioutil.WriteFile(filepath.Join(s.recordDir, name), body, 0644)
+}
+
+// decompress returns a reader that decompresses the body of the given response in
+// case if it is compressed with gzip or deflate but was not decompressed by the
+// HTTP client, for instance because a custom transport requested the compression
+// explicitly. The body is returned as is otherwise.
+func decompress(resp *http.Response) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get(headerContentEncoding))) {
+	case "gzip":
+		return gzip.NewReader(resp.Body)
+	case "deflate":
+		return zlib.NewReader(resp.Body)
+	default:
+		return resp.Body, nil
+	}
+}
+
+// fetchPage sends a request to the given path and parses the response's body as
+// an HTML page, which is returned along with the response's headers. The page is
+// fetched using a custom Fetcher in case if it was provided, in which case the
+// headers are nil.
+//
+// ErrBreakNotFound is returned when the requested page does not exist.
+func (s *Scraper) fetchPage(ctx context.Context, path string) (*html.Node, http.Header, error) {
+	if s.fetcher != nil {
+		node, err := s.fetcher.Fetch(ctx, path)
+		return node, nil, err
+	}
+
+	body, header, err := s.fetch(ctx, path)
+	if err != nil {
+		return nil, nil, err
 	}
+
+	node, err := s.parseHTML(bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, &ScrapeError{
+			Endpoint: path,
+			Stage:    StageParse,
+			Err:      fmt.Errorf("could not parse response body as html: %w", err),
+		}
+	}
+
+	return node, header, nil
+}
+
+// responseHeaders returns the given response headers in case if Scraper is
+// configured to expose them, and nil otherwise.
+func (s *Scraper) responseHeaders(h http.Header) http.Header {
+	if !s.exposeResponseHeaders {
+		return nil
+	}
+	return h
 }
 
 // Option is an optional function for configuring a Scraper.
@@ -42,20 +334,78 @@ type Option func(*options)
 
 // options holds all the options available for configuring a Scraper.
 type options struct {
-	httpClient *http.Client
-	timezones  *timezone.Timezone
+	httpClient            *http.Client
+	transport             http.RoundTripper
+	timezones             *timezone.Timezone
+	parseHTML             func(io.Reader) (*html.Node, error)
+	acceptLanguage        string
+	decorateRequest       func(*http.Request) error
+	fallbackOffsets       map[string]int
+	forecastTable         ForecastTable
+	logger                *log.Logger
+	lenientParsing        bool
+	fetcher               Fetcher
+	forecastLocation      *time.Location
+	consistencyChecks     bool
+	rawValues             bool
+	strictScraping        bool
+	timezoneAbbreviations map[string]string
+	consentCookie         *http.Cookie
+	connectionPoolSize    int
+	maxDays               int
+	numberFormat          NumberFormat
+	now                   func() time.Time
+	recordDir             string
+	baseURL               string
+	timeout               *time.Duration
+	exposeResponseHeaders bool
+	issueDateTolerance    time.Duration
+	issueDateValidation   ValidationMode
+	maxSwellsPerHour      int
+	ratingFunc            RatingFunc
 	// TODO allow authentication to fetch even more detailed reports
 }
 
 // resolveHTTPClient returns either a custom HTTP client or the default one in case
-// if no custom client was provided.
+// if no custom client was provided. The default client uses a custom transport in
+// case if it was provided.
 func (o options) resolveHTTPClient() *http.Client {
 	if o.httpClient != nil {
 		return o.httpClient
 	}
 	return &http.Client{
-		Timeout: defaultRequestTimeout,
+		Transport: o.resolveTransport(),
+		Timeout:   o.resolveTimeout(),
+	}
+}
+
+// resolveTimeout returns either a custom request timeout or the default one in
+// case if no custom timeout was provided.
+func (o options) resolveTimeout() time.Duration {
+	if o.timeout != nil {
+		return *o.timeout
 	}
+	return defaultRequestTimeout
+}
+
+// resolveTransport returns either a custom transport, or a copy of the default one
+// that keeps the given number of idle connections in case if a connection pool
+// size was provided. Nil is returned otherwise, so that the default transport is
+// used as is.
+func (o options) resolveTransport() http.RoundTripper {
+	if o.transport != nil || o.connectionPoolSize <= 0 {
+		return o.transport
+	}
+
+	defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil
+	}
+
+	t := defaultTransport.Clone()
+	t.MaxIdleConns = o.connectionPoolSize
+	t.MaxIdleConnsPerHost = o.connectionPoolSize
+	return t
 }
 
 func (o options) resolveTimezones() *timezone.Timezone {
@@ -65,16 +415,352 @@ func (o options) resolveTimezones() *timezone.Timezone {
 	return timezone.New()
 }
 
-// WithHTTPClient sets a custom HTTP client for Scraper.
+// resolveHTMLParser returns either a custom HTML parsing function or html.Parse
+// in case if no custom function was provided.
+func (o options) resolveHTMLParser() func(io.Reader) (*html.Node, error) {
+	if o.parseHTML != nil {
+		return o.parseHTML
+	}
+	return html.Parse
+}
+
+// resolveBaseURL returns either a custom base URL or the one of
+// www.surf-forecast.com in case if no custom base URL was provided.
+func (o options) resolveBaseURL() string {
+	if o.baseURL != "" {
+		return strings.TrimSuffix(o.baseURL, "/")
+	}
+	return baseURL
+}
+
+// resolveClock returns either a custom function that returns the current time or
+// time.Now in case if no custom function was provided.
+func (o options) resolveClock() func() time.Time {
+	if o.now != nil {
+		return o.now
+	}
+	return time.Now
+}
+
+// resolveAcceptLanguage returns either a custom value of the Accept-Language header
+// or the default one in case if no custom value was provided.
+func (o options) resolveAcceptLanguage() string {
+	if o.acceptLanguage != "" {
+		return o.acceptLanguage
+	}
+	return defaultAcceptLanguage
+}
+
+// resolveFallbackOffsets returns the built-in fallback UTC offsets extended with
+// custom ones in case if they were provided.
+func (o options) resolveFallbackOffsets() map[string]int {
+	offsets := make(map[string]int, len(fallbackOffsets)+len(o.fallbackOffsets))
+	for abbr, offset := range fallbackOffsets {
+		offsets[abbr] = offset
+	}
+	for abbr, offset := range o.fallbackOffsets {
+		offsets[abbr] = offset
+	}
+	return offsets
+}
+
+// resolveLogger returns either a custom logger or the one that discards everything
+// in case if no custom logger was provided.
+func (o options) resolveLogger() *log.Logger {
+	if o.logger != nil {
+		return o.logger
+	}
+	return log.New(io.Discard, "", 0)
+}
+
+// WithHTTPClient sets a custom HTTP client for Scraper. The client is used as is,
+// so it takes precedence over WithTransport and WithTimeout, and the default
+// request timeout does not apply to it.
 func WithHTTPClient(c *http.Client) Option {
 	return func(o *options) {
 		o.httpClient = c
 	}
 }
 
+// WithTransport sets a custom transport for the default HTTP client of Scraper.
+// Unlike WithHTTPClient, it keeps the default request timeout of 10 seconds or the
+// one set using WithTimeout. The option is ignored when a custom HTTP client is set using WithHTTPClient.
+func WithTransport(t http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = t
+	}
+}
+
 // WithTimezone sets a custom timezone.Timezone for Scraper.
 func WithTimezone(t *timezone.Timezone) Option {
 	return func(o *options) {
 		o.timezones = t
 	}
 }
+
+// WithHTMLParser sets a custom function for parsing HTML pages for Scraper. It can
+// be used, for instance, for sanitizing malformed markup before parsing it.
+func WithHTMLParser(fn func(io.Reader) (*html.Node, error)) Option {
+	return func(o *options) {
+		o.parseHTML = fn
+	}
+}
+
+// WithAcceptLanguage sets a custom value of the Accept-Language header that Scraper
+// sends with every request. By default, English is requested since it is the
+// markup the scraping logic is written against, so this option is only meant for
+// pinning a particular variant of it, for instance "en-GB".
+func WithAcceptLanguage(lang string) Option {
+	return func(o *options) {
+		o.acceptLanguage = lang
+	}
+}
+
+// WithRequestDecorator sets a function that Scraper calls for every request right
+// before sending it, for instance, in order to sign it. When the function returns
+// an error, the request is not sent and the error is returned to the caller.
+func WithRequestDecorator(fn func(*http.Request) error) Option {
+	return func(o *options) {
+		o.decorateRequest = fn
+	}
+}
+
+// WithFallbackOffsets extends the built-in table of fixed UTC offsets that Scraper
+// falls back to when a timezone abbreviation cannot be resolved to an IANA time
+// location, for instance when running in a container without the IANA timezone
+// database. The given map holds offsets in seconds east of UTC keyed by timezone
+// abbreviations, and it takes precedence over the built-in table.
+func WithFallbackOffsets(offsets map[string]int) Option {
+	return func(o *options) {
+		o.fallbackOffsets = offsets
+	}
+}
+
+// WithForecastTable sets the variant of the forecast table that Scraper scrapes
+// forecasts from. By default, the basic forecast table is used. When the requested
+// variant is not present on a page, Scraper falls back to the basic one and logs
+// a warning.
+func WithForecastTable(t ForecastTable) Option {
+	return func(o *options) {
+		o.forecastTable = t
+	}
+}
+
+// WithLogger sets a logger that Scraper uses for reporting warnings, for instance
+// about falling back to a different variant of the forecast table. By default,
+// nothing is logged.
+func WithLogger(l *log.Logger) Option {
+	return func(o *options) {
+		o.logger = l
+	}
+}
+
+// WithLenientParsing makes Scraper clamp scraped values that fall outside of their
+// expected range, for instance wind direction degrees or ratings, and log a warning
+// about them instead of failing. By default, such values are reported as errors.
+func WithLenientParsing() Option {
+	return func(o *options) {
+		o.lenientParsing = true
+	}
+}
+
+// WithFetcher sets a custom Fetcher that Scraper uses for fetching the pages it
+// scrapes instead of sending HTTP requests itself. Options that configure the
+// HTTP requests, such as WithHTTPClient or WithRequestDecorator, do not apply to
+// the pages fetched by it. Raw pages returned by FetchBreakHTML and
+// FetchForecastHTML are always fetched over HTTP.
+func WithFetcher(f Fetcher) Option {
+	return func(o *options) {
+		o.fetcher = f
+	}
+}
+
+// WithForecastLocation sets a time location that Scraper uses for the timestamps
+// of all forecasts instead of resolving it from the timezone abbreviation displayed
+// by www.surf-forecast.com. The timestamps keep the wall-clock values displayed by
+// the site. It is meant for surf breaks whose timezone abbreviation is ambiguous
+// and gets resolved to a wrong location.
+func WithForecastLocation(l *time.Location) Option {
+	return func(o *options) {
+		o.forecastLocation = l
+	}
+}
+
+// WithConsistencyChecks makes Scraper cross-check the scraped data against other
+// parts of the page that display the same information, and log a warning when
+// they disagree, for instance when the number of swells in the JSON payload of a
+// cell differs from the number of swell arrows rendered in it. It also checks that
+// the hours of each day are in strictly increasing order. Such warnings signal
+// that www.surf-forecast.com has changed its markup. By default, the checks are
+// not performed.
+func WithConsistencyChecks() Option {
+	return func(o *options) {
+		o.consistencyChecks = true
+	}
+}
+
+// WithRawValues sets whether Scraper attaches the texts it parsed the values of
+// hourly forecasts from to HourlyForecast.Raw, which helps diagnosing values that
+// get parsed incorrectly. By default, the texts are not kept.
+func WithRawValues(enabled bool) Option {
+	return func(o *options) {
+		o.rawValues = enabled
+	}
+}
+
+// WithStrictScraping sets whether Scraper requires the optional rows of the
+// forecast table, namely the tide and the confidence rows, and fails when they are
+// missing, which signals that www.surf-forecast.com has changed its markup. By
+// default, missing optional rows are skipped and the corresponding fields are left
+// empty.
+func WithStrictScraping(enabled bool) Option {
+	return func(o *options) {
+		o.strictScraping = enabled
+	}
+}
+
+// WithTimezoneAbbreviations registers custom timezone abbreviations that Scraper
+// resolves to the given IANA time locations, for instance "WITA" to "Asia/Makassar".
+// It is meant for abbreviations used by www.surf-forecast.com that timezone.Timezone
+// does not know or resolves to a wrong location. The given map is keyed by the
+// abbreviations, and it takes precedence over timezone.Timezone.
+func WithTimezoneAbbreviations(abbrs map[string]string) Option {
+	return func(o *options) {
+		o.timezoneAbbreviations = abbrs
+	}
+}
+
+// WithConsentCookie sets a cookie that Scraper sends with every request in order to
+// skip the cookie consent page that www.surf-forecast.com might return instead of
+// the requested one, for instance to requests coming from new IP addresses. The
+// cookie is expected to be copied from a browser session that has given consent.
+func WithConsentCookie(c *http.Cookie) Option {
+	return func(o *options) {
+		o.consentCookie = c
+	}
+}
+
+// WithConnectionPool sets the number of idle connections to www.surf-forecast.com
+// that the default HTTP client of Scraper keeps open for reuse. By default, only 2
+// idle connections are kept, which makes concurrent scraping, for instance using
+// SearchBreaksMany, open and close connections over and over again. The option is
+// ignored when a custom HTTP client or transport is set.
+func WithConnectionPool(size int) Option {
+	return func(o *options) {
+		o.connectionPoolSize = size
+	}
+}
+
+// WithMaxSwellsPerHour limits the number of swells that Scraper keeps for each hour
+// to the given number. The primary swell is always kept, and the largest secondary
+// swells by wave height fill the rest in their original order. All swells are kept
+// when the number is 0, which is the default.
+func WithMaxSwellsPerHour(n int) Option {
+	return func(o *options) {
+		o.maxSwellsPerHour = n
+	}
+}
+
+// WithMaxDays limits the number of days that Scraper scrapes from a forecast table
+// to the given number of the first days. Scraping of each row stops as soon as the
+// limit is reached, which makes scraping cheaper when only the near-term days are
+// needed. Only the hours row is scraped for one more day, which is needed for
+// telling whether the first day is partial. By default, all days are scraped.
+func WithMaxDays(n int) Option {
+	return func(o *options) {
+		o.maxDays = n
+	}
+}
+
+// WithNumberFormat sets the format of decimal numbers that Scraper expects in the
+// scraped pages, for instance when a localized page is requested using
+// WithAcceptLanguage. By default, NumberFormatDot is expected.
+func WithNumberFormat(f NumberFormat) Option {
+	return func(o *options) {
+		o.numberFormat = f
+	}
+}
+
+// WithClock sets a function that Scraper uses for getting the current time, for
+// instance when resolving relative issue timestamps such as "Issued 3 hours ago".
+// It is meant for making scraping deterministic in tests. By default, time.Now is
+// used.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
+
+// WithResponseRecorder makes Scraper write the body of each page it fetches to a
+// file in the given existing directory, named after the page's escaped path. The
+// recorded pages can be used as test fixtures, for instance by serving them from a
+// local server that Scraper is pointed at using WithBaseURL. Responses of surf
+// break searches are not recorded.
+func WithResponseRecorder(dir string) Option {
+	return func(o *options) {
+		o.recordDir = dir
+	}
+}
+
+// WithBaseURL sets a custom base URL that Scraper sends requests to instead of
+// https://www.surf-forecast.com, for instance the URL of a local server that
+// replays recorded pages.
+func WithBaseURL(u string) Option {
+	return func(o *options) {
+		o.baseURL = u
+	}
+}
+
+// WithTimeout sets a custom request timeout for the default HTTP client of Scraper
+// instead of the default one of 10 seconds. A timeout of 0 disables the timeout
+// entirely, so that requests are only bound to the deadlines of their contexts.
+// The option is ignored when a custom HTTP client is set using WithHTTPClient.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.timeout = &d
+	}
+}
+
+// WithExposeResponseHeaders sets whether Scraper attaches the headers of the
+// responses that forecasts and surf breaks were scraped from to their
+// ResponseHeaders fields, for instance for diagnosing stale copies served by a CDN
+// using the Age or X-Cache headers. The headers are not attached when pages are
+// fetched using a custom Fetcher. By default, the headers are not attached.
+func WithExposeResponseHeaders(enabled bool) Option {
+	return func(o *options) {
+		o.exposeResponseHeaders = enabled
+	}
+}
+
+// ValidationMode is a way Scraper reacts to scraped data that fails a validation.
+type ValidationMode int
+
+const (
+	// ValidationOff disables the validation.
+	ValidationOff ValidationMode = iota
+	// ValidationWarn makes Scraper log the failure and carry on.
+	ValidationWarn
+	// ValidationFail makes Scraper fail scraping.
+	ValidationFail
+)
+
+// WithIssueDateValidation makes Scraper validate that the issue timestamp of a
+// forecast is no further than the given tolerance from the current time of the
+// clock set using WithClock, guarding against archived or cached pages being
+// scraped as if they were current. The given mode selects whether such forecasts
+// are logged or fail with ErrIssueDateOutOfRange. The validation is off by default.
+func WithIssueDateValidation(tolerance time.Duration, mode ValidationMode) Option {
+	return func(o *options) {
+		o.issueDateTolerance = tolerance
+		o.issueDateValidation = mode
+	}
+}
+
+// WithRatingFunc sets a custom function that Scraper.AdjustedRating and
+// Scraper.Derived compute adjusted rating scores with. By default,
+// DefaultRatingFunc is used.
+func WithRatingFunc(fn RatingFunc) Option {
+	return func(o *options) {
+		o.ratingFunc = fn
+	}
+}
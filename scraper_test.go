@@ -0,0 +1,190 @@
+package surfforecast
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newFixtureServer starts a server that serves the fixtures of the testdata
+// directory as the pages of the Uluwatu surf break along with its search results.
+// The search results are compressed with gzip when the request accepts it.
+func newFixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/breaks/ac_location_name", func(w http.ResponseWriter, r *http.Request) {
+		results := []byte(`[['1','Uluwatu','Indonesia'],['2','Uluwatu Outside','Bali','Indonesia']]`)
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Write(results)
+			return
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		gw := gzip.NewWriter(w)
+		gw.Write(results)
+		gw.Close()
+	})
+	mux.HandleFunc("/breaks/Uluwatu", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, filepath.Join("testdata", "break.html"))
+	})
+	mux.HandleFunc("/breaks/Uluwatu/forecasts/latest", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, filepath.Join("testdata", "forecast.html"))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestScraper_Fetch_BaseURLWithPath(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/proxy/breaks/Uluwatu/forecasts/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("forecast"))
+	})
+	mux.HandleFunc("/proxy/breaks/Bali/forecasts/latest", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/proxy/countries/Indonesia", http.StatusFound)
+	})
+	mux.HandleFunc("/proxy/countries/Indonesia", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("country"))
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	s := New(WithBaseURL(srv.URL + "/proxy/"))
+
+	body, _, err := s.fetch(context.Background(), "/breaks/Uluwatu/forecasts/latest")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != "forecast" {
+		t.Errorf("got %q, want %q", body, "forecast")
+	}
+
+	_, _, err = s.fetch(context.Background(), "/breaks/Bali/forecasts/latest")
+	if !errors.Is(err, ErrBreakHasNoForecast) {
+		t.Errorf("got %v, want %v", err, ErrBreakHasNoForecast)
+	}
+}
+
+const (
+	concurrentBreakPage = `<html><body>
+<div id="dropformcont-nav">
+  <select id="country_id"><option selected>Indonesia</option></select>
+  <select id="location_filename_part"><option selected>Uluwatu</option></select>
+</div>
+</body></html>`
+
+	concurrentForecastPage = `<html><body>
+<div class="break-header" data-timezone="Asia/Makassar">
+  <div class="break-header__issued">Uluwatu Surf Forecast issued at 4 PM Sun 31 Oct 2021 WITA</div>
+</div>
+<table class="forecast-table__basic"><tbody>
+  <tr class="forecast-table__row" data-row-name="days">
+    <th>Day</th>
+    <td class="forecast-table__cell is-day-end">Sun 31</td>
+  </tr>
+  <tr class="forecast-table__row" data-row-name="time">
+    <th>Time</th>
+    <td class="forecast-table__cell"><span class="forecast-table__value">6</span><span class="forecast-table__value">PM</span></td>
+    <td class="forecast-table__cell is-day-end"><span class="forecast-table__value">9</span><span class="forecast-table__value">PM</span></td>
+  </tr>
+  <tr class="forecast-table__row" data-row-name="rating">
+    <th>Rating</th>
+    <td class="forecast-table__cell"><img alt="3"></td>
+    <td class="forecast-table__cell is-day-end"><img alt="4"></td>
+  </tr>
+  <tr class="forecast-table__row" data-row-name="wave-height">
+    <th>Wave Height</th>
+    <td class="forecast-table__cell" data-swell-state='[{"period":12,"angle":45,"letters":"SW","height":1.5}]'>
+      <span class="forecast-table__value">1.5</span>
+    </td>
+    <td class="forecast-table__cell is-day-end" data-swell-state='[{"period":13,"angle":45,"letters":"SW","height":1.6}]'>
+      <span class="forecast-table__value">1.6</span>
+    </td>
+  </tr>
+  <tr class="forecast-table__row" data-row-name="energy">
+    <th>Energy (kJ)</th>
+    <td class="forecast-table__cell"><strong>420</strong></td>
+    <td class="forecast-table__cell is-day-end"><strong>450</strong></td>
+  </tr>
+  <tr class="forecast-table__row" data-row-name="wind">
+    <th>Wind</th>
+    <td class="forecast-table__cell"><div class="wind-icon" data-speed="0"></div></td>
+    <td class="forecast-table__cell is-day-end"><div class="wind-icon" data-speed="0"></div></td>
+  </tr>
+  <tr class="forecast-table__row" data-row-name="wind-state">
+    <th>Wind State</th>
+    <td class="forecast-table__cell">glassy</td>
+    <td class="forecast-table__cell is-day-end">glassy</td>
+  </tr>
+</tbody></table>
+</body></html>`
+)
+
+// TestScraper_Concurrent sends searches and forecast requests through a single
+// Scraper concurrently. It is meant to be run with the race detector.
+func TestScraper_Concurrent(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/breaks/ac_location_name", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[['1','Uluwatu','Indonesia'],['2','Uluwatu Outside','Indonesia']]`))
+	})
+	mux.HandleFunc("/breaks/Uluwatu", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(concurrentBreakPage))
+	})
+	mux.HandleFunc("/breaks/Uluwatu/forecasts/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(concurrentForecastPage))
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var (
+		s  = New(WithBaseURL(srv.URL))
+		wg sync.WaitGroup
+	)
+
+	const goroutines = 20
+	errs := make(chan error, 3*goroutines)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			breaks, err := s.SearchBreaks("Uluwatu")
+			if err == nil && len(breaks) != 2 {
+				err = fmt.Errorf("got %d surf breaks, want 2", len(breaks))
+			}
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			f, err := s.EightDaysForecast("Uluwatu")
+			if err == nil && len(f.Daily) != 1 {
+				err = fmt.Errorf("got %d days, want 1", len(f.Daily))
+			}
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := s.Break("Uluwatu")
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
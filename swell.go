@@ -0,0 +1,19 @@
+package surfforecast
+
+import "math"
+
+// AngleToShore returns the angle in degrees between the direction the swell comes
+// from and the direction a surf break's shore faces towards the sea, ranging from 0
+// for a swell that comes straight at the shore to 180 for a swell that comes from
+// directly behind it, that is offshore.
+func (s Swell) AngleToShore(shoreFacingDegrees float64) float64 {
+	from := math.Mod(s.DirectionToInDegrees+180, 360)
+	return angleBetween(from, shoreFacingDegrees)
+}
+
+// IsWorkable checks if the swell comes at a surf break's shore facing the given
+// direction at the given angle in degrees or less, so that it can wrap into the
+// surf break. See AngleToShore.
+func (s Swell) IsWorkable(shoreFacingDegrees, maxAngle float64) bool {
+	return s.AngleToShore(shoreFacingDegrees) <= maxAngle
+}
@@ -0,0 +1,311 @@
+package surfforecast
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ztimes2/surfforecast-go/internal/htmlutil"
+	"golang.org/x/net/html"
+)
+
+const (
+	dataRowNameHighTide = "high-tide"
+	dataRowNameLowTide  = "low-tide"
+)
+
+// tideTextPattern matches a tide extreme's time and height as they are displayed
+// in the cells of the tide rows, for instance "4:52AM 1.8m".
+var tideTextPattern = regexp.MustCompile(`(\d{1,2}):(\d{2})\s*([AaPp][Mm])\s+(-?\d+(?:[.,]\d+)?)\s*m?`)
+
+// TideType is a type of a tide extreme.
+type TideType int
+
+const (
+	// TideLow is a low tide.
+	TideLow TideType = iota
+	// TideHigh is a high tide.
+	TideHigh
+)
+
+// String returns a human-readable name of the tide type.
+func (t TideType) String() string {
+	if t == TideHigh {
+		return "high"
+	}
+	return "low"
+}
+
+// Tide holds information about a tide extreme.
+type Tide struct {
+	Type TideType
+
+	// Timestamp holds a timestamp of the tide extreme using the surf break's local
+	// timezone.
+	Timestamp      time.Time
+	HeightInMeters float64
+}
+
+// NextTide returns the first tide extreme of the day that comes after the given
+// time. False is returned when there is no such tide extreme.
+func (d DailyForecast) NextTide(after time.Time) (Tide, bool) {
+	return d.nextTide(after, func(Tide) bool { return true })
+}
+
+// NextHighTide returns the first high tide of the day that comes after the given
+// time. False is returned when there is no such tide.
+func (d DailyForecast) NextHighTide(after time.Time) (Tide, bool) {
+	return d.nextTide(after, func(t Tide) bool { return t.Type == TideHigh })
+}
+
+// NextLowTide returns the first low tide of the day that comes after the given
+// time. False is returned when there is no such tide.
+func (d DailyForecast) NextLowTide(after time.Time) (Tide, bool) {
+	return d.nextTide(after, func(t Tide) bool { return t.Type == TideLow })
+}
+
+func (d DailyForecast) nextTide(after time.Time, matches func(Tide) bool) (Tide, bool) {
+	for _, t := range d.Tides {
+		if t.Timestamp.After(after) && matches(t) {
+			return t, true
+		}
+	}
+	return Tide{}, false
+}
+
+// TideHeightAt approximates the height of the tide at the given time using the
+// day's tide extremes that surround it. Between two subsequent extremes, the tide
+// is modelled as half of a cosine wave, so it changes slowly around the extremes
+// and fastest halfway between them:
+//
+//	height = h1 + (h2 - h1) * (1 - cos(π * elapsed / duration)) / 2
+//
+// where h1 and h2 are the heights of the surrounding extremes, duration is the
+// time between them and elapsed is the time since the first one. False is returned
+// when the given time is not surrounded by the day's tide extremes.
+func (d DailyForecast) TideHeightAt(t time.Time) (float64, bool) {
+	for i := 1; i < len(d.Tides); i++ {
+		from, to := d.Tides[i-1], d.Tides[i]
+		if t.Before(from.Timestamp) || t.After(to.Timestamp) {
+			continue
+		}
+
+		duration := to.Timestamp.Sub(from.Timestamp)
+		if duration == 0 {
+			return from.HeightInMeters, true
+		}
+
+		elapsed := t.Sub(from.Timestamp)
+		ratio := (1 - math.Cos(math.Pi*float64(elapsed)/float64(duration))) / 2
+		return from.HeightInMeters + (to.HeightInMeters-from.HeightInMeters)*ratio, true
+	}
+	return 0, false
+}
+
+const (
+	// synodicMonth is the average duration of the lunar phase cycle.
+	synodicMonth = time.Duration(29.530588853 * 24 * float64(time.Hour))
+
+	// springTideWindow is the time before and after a new or a full moon during
+	// which tides are considered spring tides. It is a quarter of the time between
+	// a new and a full moon, so spring and neap tides split the cycle evenly.
+	springTideWindow = synodicMonth / 8
+)
+
+// referenceNewMoon is a known new moon that the lunar phase cycle is counted from.
+var referenceNewMoon = time.Date(2000, time.January, 6, 18, 14, 0, 0, time.UTC)
+
+// Tide phases returned by DailyForecast.TidePhase.
+const (
+	TidePhaseSpring = "spring"
+	TidePhaseNeap   = "neap"
+)
+
+// TideRangeInMeters returns the difference between the highest and the lowest
+// tide extremes of the day. 0 is returned when the day has less than 2 tide
+// extremes.
+func (d DailyForecast) TideRangeInMeters() float64 {
+	if len(d.Tides) < 2 {
+		return 0
+	}
+
+	lowest, highest := d.Tides[0].HeightInMeters, d.Tides[0].HeightInMeters
+	for _, t := range d.Tides[1:] {
+		lowest = math.Min(lowest, t.HeightInMeters)
+		highest = math.Max(highest, t.HeightInMeters)
+	}
+
+	return highest - lowest
+}
+
+// TidePhase returns TidePhaseSpring when the day falls around a new or a full moon,
+// when tides have the largest ranges, and TidePhaseNeap otherwise.
+//
+// www.surf-forecast.com does not display tidal coefficients, and the tidal range of
+// a single day cannot tell spring and neap tides apart without knowing the typical
+// range of the location. Therefore, the phase is derived from the age of the moon
+// at midday instead, so it is a rough indicator that ignores the local lag between
+// the moon phase and the tides. Use TideRangeInMeters in order to compare the days
+// of a forecast with each other.
+func (d DailyForecast) TidePhase() string {
+	midday := d.Timestamp.Add(12 * time.Hour)
+
+	age := midday.Sub(referenceNewMoon) % synodicMonth
+	if age < 0 {
+		age += synodicMonth
+	}
+
+	// Distance to the closest new or full moon.
+	distance := age % (synodicMonth / 2)
+	if distance > synodicMonth/4 {
+		distance = synodicMonth/2 - distance
+	}
+
+	if distance <= springTideWindow {
+		return TidePhaseSpring
+	}
+	return TidePhaseNeap
+}
+
+// tide holds a scraped tide extreme before it gets attached to a particular day.
+type tide struct {
+	typ    TideType
+	hour   int
+	minute int
+	height float64
+}
+
+// scrapeTides scrapes the tide extremes of both tide rows and merges them into
+// chronologically ordered tides per day. Nil is returned when the table has no
+// tide rows, unless strict scraping is enabled.
+func (s *Scraper) scrapeTides(t forecastTable) ([][]tide, error) {
+	highTides, err := s.scrapeTideRow(t, dataRowNameHighTide, TideHigh)
+	if err != nil {
+		return nil, fmt.Errorf("could not scrape high tides: %w", err)
+	}
+
+	lowTides, err := s.scrapeTideRow(t, dataRowNameLowTide, TideLow)
+	if err != nil {
+		return nil, fmt.Errorf("could not scrape low tides: %w", err)
+	}
+
+	switch {
+	case highTides == nil:
+		return lowTides, nil
+	case lowTides == nil:
+		return highTides, nil
+	case len(highTides) != len(lowTides):
+		return nil, fmt.Errorf("high and low tides must have equal number of days")
+	}
+
+	allTides := make([][]tide, len(highTides))
+	for i := range allTides {
+		tides := append(append([]tide{}, highTides[i]...), lowTides[i]...)
+		sort.Slice(tides, func(a, b int) bool {
+			if tides[a].hour != tides[b].hour {
+				return tides[a].hour < tides[b].hour
+			}
+			return tides[a].minute < tides[b].minute
+		})
+		allTides[i] = tides
+	}
+
+	return allTides, nil
+}
+
+func (s *Scraper) scrapeTideRow(t forecastTable, rowName string, typ TideType) ([][]tide, error) {
+	tidesNode, ok := t.rows[rowName]
+	if !ok {
+		if s.strictScraping {
+			return nil, errors.New("could not find tides node")
+		}
+		return nil, nil
+	}
+
+	var (
+		allTides = [][]tide{}
+		tides    []tide
+	)
+	if err := t.forEachCell(tidesNode, func(n *html.Node) error {
+		cellTides, err := scrapeCellTides(n, typ, s.numberFormat)
+		if err != nil {
+			return fmt.Errorf("could not scrape tide: %w", err)
+		}
+
+		tides = append(tides, cellTides...)
+
+		isDayEnd := htmlutil.ClassContains(n, classIsDayEnd)
+		if isDayEnd {
+			allTides = append(allTides, tides)
+			tides = []tide{}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return allTides, nil
+}
+
+// scrapeCellTides scrapes all tide extremes that are displayed in the given cell.
+// A cell might contain none, one or a few of them. The heights are written in the
+// given number format.
+func scrapeCellTides(n *html.Node, typ TideType, f NumberFormat) ([]tide, error) {
+	var ss []string
+	htmlutil.ForEach(n, func(n *html.Node) error {
+		if n.Type == html.TextNode {
+			ss = append(ss, n.Data)
+		}
+		return nil
+	})
+
+	var tides []tide
+	for _, match := range tideTextPattern.FindAllStringSubmatch(strings.Join(ss, " "), -1) {
+		hour, err := parseTwelveClockHour(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("could not parse tide hour: %w", err)
+		}
+
+		minute, err := parseMinute(match[2])
+		if err != nil {
+			return nil, fmt.Errorf("could not parse tide minute: %w", err)
+		}
+
+		period, err := parseClockPeriod(match[3])
+		if err != nil {
+			return nil, fmt.Errorf("could not parse tide clock period: %w", err)
+		}
+
+		height, err := f.parseFloat(match[4])
+		if err != nil {
+			return nil, fmt.Errorf("could not parse tide height: %w", err)
+		}
+
+		tides = append(tides, tide{
+			typ:    typ,
+			hour:   toTwentyFourClockHour(hour, period),
+			minute: minute,
+			height: height,
+		})
+	}
+
+	return tides, nil
+}
+
+func parseMinute(s string) (int, error) {
+	minute, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("not integer: %q", s)
+	}
+
+	if minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("not minute: %q", s)
+	}
+
+	return minute, nil
+}
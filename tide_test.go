@@ -0,0 +1,63 @@
+package surfforecast
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDailyForecast_TideHeightAt(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2021, time.November, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	d := DailyForecast{
+		Tides: []Tide{
+			{Type: TideLow, Timestamp: at(0, 0), HeightInMeters: 0.4},
+			{Type: TideHigh, Timestamp: at(6, 0), HeightInMeters: 2.4},
+			{Type: TideLow, Timestamp: at(12, 0), HeightInMeters: 0.2},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		t      time.Time
+		want   float64
+		wantOK bool
+	}{
+		{name: "first extreme", t: at(0, 0), want: 0.4, wantOK: true},
+		{name: "rising halfway", t: at(3, 0), want: 1.4, wantOK: true},
+		{name: "rising third", t: at(2, 0), want: 0.4 + 2*0.25, wantOK: true},
+		{name: "middle extreme", t: at(6, 0), want: 2.4, wantOK: true},
+		{name: "falling halfway", t: at(9, 0), want: 1.3, wantOK: true},
+		{name: "last extreme", t: at(12, 0), want: 0.2, wantOK: true},
+		{name: "before first extreme", t: at(0, 0).Add(-time.Minute)},
+		{name: "after last extreme", t: at(12, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := d.TideHeightAt(tt.t)
+			if ok != tt.wantOK {
+				t.Fatalf("got %v, want %v", ok, tt.wantOK)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("single extreme", func(t *testing.T) {
+		single := DailyForecast{Tides: d.Tides[:1]}
+		if _, ok := single.TideHeightAt(at(0, 0)); ok {
+			t.Error("got true, want false")
+		}
+	})
+
+	t.Run("coinciding extremes", func(t *testing.T) {
+		same := DailyForecast{Tides: []Tide{d.Tides[0], {Type: TideHigh, Timestamp: at(0, 0), HeightInMeters: 2}}}
+		if got, ok := same.TideHeightAt(at(0, 0)); !ok || got != 0.4 {
+			t.Errorf("got %v %v, want 0.4 true", got, ok)
+		}
+	})
+}
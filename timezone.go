@@ -0,0 +1,258 @@
+package surfforecast
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/tkuchiki/go-timezone"
+	"github.com/ztimes2/surfforecast-go/internal/htmlutil"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	// ErrTimezoneUnresolved indicates that a timezone abbreviation used by
+	// www.surf-forecast.com could not be resolved to a time location. Use errors.As
+	// with TimezoneError in order to find out the abbreviation.
+	ErrTimezoneUnresolved = errors.New("timezone unresolved")
+)
+
+// TimezoneError holds information about a timezone abbreviation that could not be
+// resolved to a time location. It matches ErrTimezoneUnresolved when using errors.Is.
+type TimezoneError struct {
+	Abbreviation string
+	Err          error
+}
+
+// Error implements error interface.
+func (e *TimezoneError) Error() string {
+	return fmt.Sprintf("could not resolve %q timezone abbreviation: %v", e.Abbreviation, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *TimezoneError) Unwrap() error {
+	return e.Err
+}
+
+// Is checks if the given target is ErrTimezoneUnresolved.
+func (e *TimezoneError) Is(target error) bool {
+	return target == ErrTimezoneUnresolved
+}
+
+// fallbackOffsets holds fixed UTC offsets in seconds of the timezone abbreviations
+// commonly used by www.surf-forecast.com. Ambiguous abbreviations are left out.
+var fallbackOffsets = map[string]int{
+	"UTC":  0,
+	"GMT":  0,
+	"WET":  0,
+	"WEST": 1 * 60 * 60,
+	"BST":  1 * 60 * 60,
+	"CET":  1 * 60 * 60,
+	"CEST": 2 * 60 * 60,
+	"WAT":  1 * 60 * 60,
+	"EET":  2 * 60 * 60,
+	"EEST": 3 * 60 * 60,
+	"SAST": 2 * 60 * 60,
+	"EAT":  3 * 60 * 60,
+	"MSK":  3 * 60 * 60,
+	"GST":  4 * 60 * 60,
+	"MVT":  5 * 60 * 60,
+	"PKT":  5 * 60 * 60,
+	"ICT":  7 * 60 * 60,
+	"WIB":  7 * 60 * 60,
+	"WITA": 8 * 60 * 60,
+	"WIT":  9 * 60 * 60,
+	"MYT":  8 * 60 * 60,
+	"SGT":  8 * 60 * 60,
+	"PHT":  8 * 60 * 60,
+	"HKT":  8 * 60 * 60,
+	"AWST": 8 * 60 * 60,
+	"JST":  9 * 60 * 60,
+	"KST":  9 * 60 * 60,
+	"ACST": 9*60*60 + 30*60,
+	"ACDT": 10*60*60 + 30*60,
+	"AEST": 10 * 60 * 60,
+	"AEDT": 11 * 60 * 60,
+	"FJT":  12 * 60 * 60,
+	"NZST": 12 * 60 * 60,
+	"NZDT": 13 * 60 * 60,
+	"HST":  -10 * 60 * 60,
+	"AKST": -9 * 60 * 60,
+	"AKDT": -8 * 60 * 60,
+	"PST":  -8 * 60 * 60,
+	"PDT":  -7 * 60 * 60,
+	"MST":  -7 * 60 * 60,
+	"MDT":  -6 * 60 * 60,
+	"CST":  -6 * 60 * 60,
+	"CDT":  -5 * 60 * 60,
+	"EST":  -5 * 60 * 60,
+	"EDT":  -4 * 60 * 60,
+	"PET":  -5 * 60 * 60,
+	"CLT":  -4 * 60 * 60,
+	"BRT":  -3 * 60 * 60,
+	"ART":  -3 * 60 * 60,
+}
+
+// resolveLocation resolves the given timezone abbreviation to a time location.
+// Custom abbreviations take precedence over the ones known to timezone.Timezone.
+// When the abbreviation cannot be resolved to an IANA time location, for instance
+// because the IANA timezone database is missing, a fixed UTC offset is used in case
+// if it is known for the abbreviation. TimezoneError is returned otherwise.
+//
+// The abbreviation is ignored when a custom forecast location was provided.
+func (s *Scraper) resolveLocation(abbr string) (*time.Location, error) {
+	if s.forecastLocation != nil {
+		return s.forecastLocation, nil
+	}
+
+	var (
+		loc *time.Location
+		err error
+	)
+	if name, ok := s.timezoneAbbreviations[abbr]; ok {
+		loc, err = loadNamedLocation(abbr, name)
+	} else {
+		loc, err = loadLocation(s.timezones, abbr)
+	}
+	if err == nil {
+		return loc, nil
+	}
+
+	if offset, ok := s.fallbackOffsets[abbr]; ok {
+		return time.FixedZone(abbr, offset), nil
+	}
+
+	return nil, err
+}
+
+// attributeDataTimezone is the key of the attribute that holds the IANA timezone
+// of the surf break on a page.
+const attributeDataTimezone = "data-timezone"
+
+// Classes of the elements whose data-timezone attribute holds the timezone of the
+// surf break rather than of something else on the page, for instance the visitor.
+const (
+	classBreakHeader = "break-header"
+	classForecast    = "forecast-table"
+)
+
+// isPageLocationElement checks if the given node is the break header or the
+// forecast table. Class names are compared as a whole, so the elements nested in
+// them, such as forecast-table__cell, do not count.
+func isPageLocationElement(n *html.Node) bool {
+	for _, class := range []string{
+		classBreakHeader,
+		classForecast,
+		classForecastTableBasic,
+		classForecastTableDetailed,
+	} {
+		if htmlutil.HasClass(n, class) {
+			return true
+		}
+	}
+	return false
+}
+
+// timezoneConfigPattern matches an IANA timezone name assigned to the surf break's
+// timezone key in the configuration scripts of a page, for instance
+// "break_timezone":"Asia/Makassar" or spotTimeZone = 'Asia/Makassar'.
+var timezoneConfigPattern = regexp.MustCompile(
+	`(?i:["']?(?:break|spot)_?time_?zone["']?)\s*[:=]\s*["']([A-Za-z]+(?:/[A-Za-z0-9_+\-]+)+)["']`,
+)
+
+// scrapePageLocation scrapes the IANA timezone of the surf break from the given
+// page, either from a data-timezone attribute of the break header or the forecast
+// table, or from the surf break's key of a configuration script, and loads it as a
+// time location. False is returned when the page holds no timezone or it cannot
+// be loaded, in which case the latter is logged.
+func (s *Scraper) scrapePageLocation(n *html.Node) (*time.Location, bool) {
+	var name string
+	htmlutil.ForEach(n, func(n *html.Node) error {
+		if isPageLocationElement(n) {
+			if attr, ok := htmlutil.Attribute(n, attributeDataTimezone); ok && attr.Val != "" {
+				name = attr.Val
+				return errStopIteration
+			}
+		}
+
+		if n.Type == html.TextNode && n.Parent != nil && n.Parent.DataAtom == atom.Script {
+			if match := timezoneConfigPattern.FindStringSubmatch(n.Data); match != nil {
+				name = match[1]
+				return errStopIteration
+			}
+		}
+		return nil
+	})
+	if name == "" {
+		return nil, false
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		s.logger.Printf("could not load time location of page timezone %q: %v", name, err)
+		return nil, false
+	}
+
+	return loc, true
+}
+
+// warnOnLocationMismatch logs when the given timezone abbreviation of the issue
+// text resolves to a different UTC offset at the given issue timestamp than the
+// page's timezone that the timestamp has been scraped in.
+func (s *Scraper) warnOnLocationMismatch(issuedAt time.Time, abbr string) {
+	abbrLoc, err := s.resolveLocation(abbr)
+	if err != nil {
+		return
+	}
+
+	_, offset := issuedAt.Zone()
+	_, abbrOffset := issuedAt.In(abbrLoc).Zone()
+	if offset != abbrOffset {
+		s.logger.Printf(
+			"page timezone %s disagrees with issue timezone abbreviation %q, using the former",
+			issuedAt.Location(), abbr,
+		)
+	}
+}
+
+// errStopIteration is used for stopping htmlutil.ForEach once the target has been
+// found.
+var errStopIteration = errors.New("stop iteration")
+
+// loadLocation resolves the given timezone abbreviation to an IANA time location.
+// TimezoneError is returned when the abbreviation could not be resolved.
+func loadLocation(tz *timezone.Timezone, abbr string) (*time.Location, error) {
+	timezones, err := tz.GetTimezones(abbr)
+	if err != nil {
+		return nil, &TimezoneError{
+			Abbreviation: abbr,
+			Err:          fmt.Errorf("could not find timezones: %w", err),
+		}
+	}
+
+	if len(timezones) == 0 {
+		return nil, &TimezoneError{
+			Abbreviation: abbr,
+			Err:          errors.New("0 timezones found"),
+		}
+	}
+
+	return loadNamedLocation(abbr, timezones[0])
+}
+
+// loadNamedLocation loads the IANA time location with the given name that the
+// given timezone abbreviation has been resolved to. TimezoneError is returned when
+// the location could not be loaded.
+func loadNamedLocation(abbr, name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, &TimezoneError{
+			Abbreviation: abbr,
+			Err:          fmt.Errorf("could not load time location for %q: %w", name, err),
+		}
+	}
+
+	return loc, nil
+}
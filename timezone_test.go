@@ -0,0 +1,107 @@
+package surfforecast
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScraper_ScrapePageLocation(t *testing.T) {
+	tests := []struct {
+		name   string
+		page   string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "break header attribute",
+			page:   `<div class="break-header" data-timezone="Asia/Makassar"></div>`,
+			want:   "Asia/Makassar",
+			wantOK: true,
+		},
+		{
+			name:   "forecast table attribute",
+			page:   `<table class="forecast-table__basic" data-timezone="Asia/Makassar"></table>`,
+			want:   "Asia/Makassar",
+			wantOK: true,
+		},
+		{
+			name:   "break config key",
+			page:   `<script>window.config = {"break_timezone":"Asia/Makassar"};</script>`,
+			want:   "Asia/Makassar",
+			wantOK: true,
+		},
+		{
+			name: "unrelated attribute",
+			page: `<div class="user-menu" data-timezone="Europe/London"></div>`,
+		},
+		{
+			name: "forecast table cell attribute",
+			page: `<table class="forecast-table__basic"><tr><td class="forecast-table__cell" data-timezone="Europe/London"></td></tr></table>`,
+		},
+		{
+			name: "break header descendant attribute",
+			page: `<div class="break-header__photo" data-timezone="Europe/London"></div>`,
+		},
+		{
+			name: "break header after forecast table cell attribute",
+			page: `<table class="forecast-table__basic"><tr><td class="forecast-table__cell" data-timezone="Europe/London"></td></tr></table>` +
+				`<div class="break-header" data-timezone="Asia/Makassar"></div>`,
+			want:   "Asia/Makassar",
+			wantOK: true,
+		},
+		{
+			name: "unrelated config key",
+			page: `<script>window.config = {"timezone":"Europe/London"};</script>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := New().scrapePageLocation(mustParseHTML(t, tt.page))
+			if ok != tt.wantOK {
+				t.Fatalf("got %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got.String() != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScraper_WarnOnLocationMismatch(t *testing.T) {
+	makassar, err := time.LoadLocation("Asia/Makassar")
+	if err != nil {
+		t.Skipf("could not load location: %v", err)
+	}
+	issuedAt := time.Date(2021, time.November, 1, 1, 0, 0, 0, makassar)
+
+	tests := []struct {
+		abbr     string
+		wantWarn bool
+	}{
+		{abbr: "WITA"},
+		{abbr: "WIB", wantWarn: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.abbr, func(t *testing.T) {
+			var logs bytes.Buffer
+			s := New(
+				WithLogger(log.New(&logs, "", 0)),
+				WithTimezoneAbbreviations(map[string]string{
+					"WITA": "Asia/Makassar",
+					"WIB":  "Asia/Jakarta",
+				}),
+			)
+
+			s.warnOnLocationMismatch(issuedAt, tt.abbr)
+
+			if gotWarn := strings.Contains(logs.String(), "disagrees"); gotWarn != tt.wantWarn {
+				t.Errorf("got warning %v, want %v: %q", gotWarn, tt.wantWarn, logs.String())
+			}
+		})
+	}
+}
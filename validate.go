@@ -0,0 +1,116 @@
+package surfforecast
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Validate checks the internal consistency of the forecast, for instance when it
+// has been loaded from a cache rather than scraped. It checks that:
+//   - the days are contiguous and in ascending order,
+//   - the hours of each day belong to the day and are in ascending order,
+//   - the ratings range from 0 to 10,
+//   - the directions in degrees range from 0 to 360,
+//   - the heights, periods, wave energies and wind speeds are not negative,
+//   - the minimum surf heights do not exceed the maximum ones.
+//
+// The first inconsistency found is returned. The ranges are the same as the ones
+// that the scraped values are checked against.
+func (f *Forecast) Validate() error {
+	for i, d := range f.Daily {
+		if d == nil {
+			return fmt.Errorf("day %d is nil", i)
+		}
+
+		if i > 0 {
+			previous := f.Daily[i-1].Timestamp
+			if !sameDate(d.Timestamp, previous.AddDate(0, 0, 1)) {
+				return fmt.Errorf(
+					"day %s does not follow day %s",
+					d.Timestamp.Format("2006-01-02"), previous.Format("2006-01-02"),
+				)
+			}
+		}
+
+		if err := d.validate(); err != nil {
+			return fmt.Errorf("invalid day %s: %w", d.Timestamp.Format("2006-01-02"), err)
+		}
+	}
+	return nil
+}
+
+func (d *DailyForecast) validate() error {
+	for i, h := range d.Hourly {
+		if !sameDate(h.Timestamp, d.Timestamp) {
+			return fmt.Errorf("hour %s does not belong to the day", h.Timestamp.Format(time.RFC3339))
+		}
+
+		if i > 0 && !h.Timestamp.After(d.Hourly[i-1].Timestamp) {
+			return fmt.Errorf(
+				"hour %s does not come after hour %s",
+				h.Timestamp.Format(time.RFC3339), d.Hourly[i-1].Timestamp.Format(time.RFC3339),
+			)
+		}
+
+		if err := h.validate(); err != nil {
+			return fmt.Errorf("invalid hour %s: %w", h.Timestamp.Format(time.RFC3339), err)
+		}
+	}
+	return nil
+}
+
+func (h HourlyForecast) validate() error {
+	if err := checkRange("rating", float64(h.Rating), minRating, maxRating); err != nil {
+		return err
+	}
+
+	swells := append([]Swell{h.Swells.Primary}, h.Swells.Secondary...)
+	for _, s := range swells {
+		if err := s.validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := checkRange("surf height", h.SurfHeightMinInMeters, 0, math.Inf(1)); err != nil {
+		return err
+	}
+	if err := checkRange("surf height", h.SurfHeightMaxInMeters, h.SurfHeightMinInMeters, math.Inf(1)); err != nil {
+		return err
+	}
+	if err := checkRange("wave energy", h.WaveEnergyInKiloJoules, 0, math.Inf(1)); err != nil {
+		return err
+	}
+	if err := checkRange("wind speed", h.Wind.SpeedInKilometersPerHour, 0, math.Inf(1)); err != nil {
+		return err
+	}
+	return checkRange("wind direction degrees", h.Wind.DirectionToInDegrees, 0, 360)
+}
+
+func (s Swell) validate() error {
+	if err := checkRange("swell period", s.PeriodInSeconds, 0, math.Inf(1)); err != nil {
+		return err
+	}
+	if err := checkRange("swell direction degrees", s.DirectionToInDegrees, 0, 360); err != nil {
+		return err
+	}
+	return checkRange("swell wave height", s.WaveHeightInMeters, 0, math.Inf(1))
+}
+
+// checkRange returns outOfRangeError when the given value falls outside of the
+// given range.
+func checkRange(name string, value, min, max float64) error {
+	if value < min || value > max || math.IsNaN(value) {
+		text := strconv.FormatFloat(value, 'f', -1, 64)
+		return newOutOfRangeError(name, text, value, min, max)
+	}
+	return nil
+}
+
+// sameDate checks if both timestamps fall on the same date in the location of the
+// first one.
+func sameDate(a, b time.Time) bool {
+	b = b.In(a.Location())
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
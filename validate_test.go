@@ -0,0 +1,109 @@
+package surfforecast
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestForecast_Validate(t *testing.T) {
+	day := func(d int, hours ...int) *DailyForecast {
+		f := &DailyForecast{Timestamp: time.Date(2021, time.October, d, 0, 0, 0, 0, time.UTC)}
+		for _, h := range hours {
+			f.Hourly = append(f.Hourly, HourlyForecast{
+				Timestamp:             f.Timestamp.Add(time.Duration(h) * time.Hour),
+				Rating:                3,
+				SurfHeightMinInMeters: 1,
+				SurfHeightMaxInMeters: 1.5,
+			})
+		}
+		return f
+	}
+	modified := func(modify func(f *Forecast)) *Forecast {
+		f := &Forecast{Daily: []*DailyForecast{day(30, 0, 12), day(31, 0, 12)}}
+		modify(f)
+		return f
+	}
+
+	tests := []struct {
+		name     string
+		forecast *Forecast
+		wantErr  bool
+	}{
+		{
+			name:     "valid",
+			forecast: modified(func(*Forecast) {}),
+		},
+		{
+			name:     "empty",
+			forecast: &Forecast{},
+		},
+		{
+			name: "month rollover",
+			forecast: &Forecast{Daily: []*DailyForecast{
+				day(31, 0),
+				{Timestamp: time.Date(2021, time.November, 1, 0, 0, 0, 0, time.UTC)},
+			}},
+		},
+		{
+			name:     "nil day",
+			forecast: modified(func(f *Forecast) { f.Daily[1] = nil }),
+			wantErr:  true,
+		},
+		{
+			name:     "gap between days",
+			forecast: modified(func(f *Forecast) { f.Daily[1] = day(29) }),
+			wantErr:  true,
+		},
+		{
+			name: "hour of another day",
+			forecast: modified(func(f *Forecast) {
+				f.Daily[0].Hourly[1].Timestamp = f.Daily[1].Timestamp
+			}),
+			wantErr: true,
+		},
+		{
+			name: "hours out of order",
+			forecast: modified(func(f *Forecast) {
+				f.Daily[0].Hourly[0], f.Daily[0].Hourly[1] = f.Daily[0].Hourly[1], f.Daily[0].Hourly[0]
+			}),
+			wantErr: true,
+		},
+		{
+			name:     "rating out of range",
+			forecast: modified(func(f *Forecast) { f.Daily[0].Hourly[0].Rating = maxRating + 1 }),
+			wantErr:  true,
+		},
+		{
+			name: "swell direction out of range",
+			forecast: modified(func(f *Forecast) {
+				f.Daily[0].Hourly[0].Swells.Secondary = []Swell{{DirectionToInDegrees: 361}}
+			}),
+			wantErr: true,
+		},
+		{
+			name:     "negative wind speed",
+			forecast: modified(func(f *Forecast) { f.Daily[1].Hourly[0].Wind.SpeedInKilometersPerHour = -1 }),
+			wantErr:  true,
+		},
+		{
+			name:     "NaN wave energy",
+			forecast: modified(func(f *Forecast) { f.Daily[1].Hourly[1].WaveEnergyInKiloJoules = math.NaN() }),
+			wantErr:  true,
+		},
+		{
+			name:     "minimum surf height above maximum",
+			forecast: modified(func(f *Forecast) { f.Daily[0].Hourly[0].SurfHeightMinInMeters = 2 }),
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.forecast.Validate()
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Errorf("got %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package surfforecast
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ForecastChange holds the latest forecast of a surf break that has changed since
+// the previous scrape along with the previous forecast. It does not tell which of
+// the values have changed, so any finer comparison is up to the caller.
+type ForecastChange struct {
+	BreakName string
+
+	// Previous holds the forecast of the previous scrape. It is nil for the first
+	// scrape of the surf break.
+	Previous *Forecast
+	Current  *Forecast
+}
+
+// Watcher periodically scrapes the latest forecasts of a set of surf breaks and
+// notifies about the ones that have changed since the previous scrape. A forecast
+// that has been re-issued with the same values does not count as changed.
+//
+// The forecasts are scraped one by one, so a Watcher never sends more than one
+// request at a time, and it waits for the given delay between the requests in
+// order to spread them out. Unlike Scraper, a Watcher keeps the previous forecasts,
+// so Run must not be called concurrently.
+type Watcher struct {
+	scraper    *Scraper
+	breakNames []string
+	interval   time.Duration
+	delay      time.Duration
+	notify     func(ForecastChange)
+
+	previous map[string]*Forecast
+}
+
+// NewWatcher returns a new Watcher that scrapes the 8-day forecasts of the given
+// surf breaks every given interval using the given Scraper, waits for the given
+// delay between the scrapes of individual surf breaks, and calls the given
+// function for each changed forecast.
+func NewWatcher(
+	s *Scraper,
+	breakNames []string,
+	interval time.Duration,
+	delay time.Duration,
+	notify func(ForecastChange)) *Watcher {
+
+	return &Watcher{
+		scraper:    s,
+		breakNames: breakNames,
+		interval:   interval,
+		delay:      delay,
+		notify:     notify,
+		previous:   make(map[string]*Forecast),
+	}
+}
+
+// Run scrapes the forecasts right away and then every interval until the given
+// context is done, in which case the context's error is returned. Failures of
+// individual surf breaks are logged using the Scraper's logger, and their
+// previous forecasts are kept for the next round. An error is returned right away
+// when the interval is not positive.
+func (w *Watcher) Run(ctx context.Context) error {
+	if w.interval <= 0 {
+		return fmt.Errorf("invalid interval: %s", w.interval)
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.poll(ctx); err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// poll scrapes the forecasts of all surf breaks once and notifies about the
+// changed ones. It returns the context's error once the context is done.
+func (w *Watcher) poll(ctx context.Context) error {
+	for i, name := range w.breakNames {
+		if i > 0 && w.delay > 0 {
+			timer := time.NewTimer(w.delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+
+		current, err := w.scraper.forecast(ctx, fmt.Sprintf(pathFormatForecastsForEightDays, name))
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			w.scraper.logger.Printf("could not scrape forecast of %s: %v", name, err)
+			continue
+		}
+
+		previous := w.previous[name]
+		if previous != nil && sameDays(previous, current) {
+			continue
+		}
+
+		w.previous[name] = current
+		w.notify(ForecastChange{
+			BreakName: name,
+			Previous:  previous,
+			Current:   current,
+		})
+	}
+	return nil
+}
+
+// sameDays checks if both forecasts hold the same daily forecasts regardless of
+// when they were issued.
+func sameDays(f, other *Forecast) bool {
+	if len(f.Daily) != len(other.Daily) {
+		return false
+	}
+
+	for i := range f.Daily {
+		if !f.Daily[i].Equal(other.Daily[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,36 @@
+package surfforecast
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatcher_Run_InvalidInterval(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer srv.Close()
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		w := NewWatcher(
+			New(WithBaseURL(srv.URL)),
+			[]string{"Uluwatu"},
+			interval,
+			0,
+			func(ForecastChange) { t.Error("unexpected notification") },
+		)
+
+		if err := w.Run(context.Background()); err == nil {
+			t.Errorf("interval %s: got nil error", interval)
+		}
+	}
+
+	if n := atomic.LoadInt32(&requests); n != 0 {
+		t.Errorf("got %d requests, want 0", n)
+	}
+}
@@ -0,0 +1,163 @@
+package surfforecast
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// WindState is a state of a wind relative to the shore.
+type WindState int
+
+const (
+	// WindStateUnknown is an unknown wind state.
+	WindStateUnknown WindState = iota
+	// WindStateGlassy is a state of little to no wind.
+	WindStateGlassy
+	// WindStateOffshore is a state of a wind blowing from the land towards the sea.
+	WindStateOffshore
+	// WindStateCrossOffshore is a state of a wind blowing across the shore and
+	// slightly towards the sea.
+	WindStateCrossOffshore
+	// WindStateCrossShore is a state of a wind blowing across the shore.
+	WindStateCrossShore
+	// WindStateCrossOnshore is a state of a wind blowing across the shore and
+	// slightly towards the land.
+	WindStateCrossOnshore
+	// WindStateOnshore is a state of a wind blowing from the sea towards the land.
+	WindStateOnshore
+)
+
+// windStates maps the scraped wind states, stripped of whitespace and hyphens,
+// to WindState.
+var windStates = map[string]WindState{
+	"glass":         WindStateGlassy,
+	"glassy":        WindStateGlassy,
+	"off":           WindStateOffshore,
+	"offshore":      WindStateOffshore,
+	"crossoff":      WindStateCrossOffshore,
+	"crossoffshore": WindStateCrossOffshore,
+	"cross":         WindStateCrossShore,
+	"crossshore":    WindStateCrossShore,
+	"crosson":       WindStateCrossOnshore,
+	"crossonshore":  WindStateCrossOnshore,
+	"on":            WindStateOnshore,
+	"onshore":       WindStateOnshore,
+}
+
+// ParseWindState parses the given wind state as it is displayed by
+// www.surf-forecast.com, for instance "Cross shore" or "cross-off". The parsing is
+// case-insensitive and ignores whitespace and hyphens.
+func ParseWindState(s string) (WindState, error) {
+	key := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '-' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, s)
+
+	state, ok := windStates[key]
+	if !ok {
+		return WindStateUnknown, fmt.Errorf("invalid wind state: %q", s)
+	}
+
+	return state, nil
+}
+
+// String returns a human-readable name of the wind state.
+func (s WindState) String() string {
+	switch s {
+	case WindStateGlassy:
+		return "glassy"
+	case WindStateOffshore:
+		return "offshore"
+	case WindStateCrossOffshore:
+		return "cross-offshore"
+	case WindStateCrossShore:
+		return "cross-shore"
+	case WindStateCrossOnshore:
+		return "cross-onshore"
+	case WindStateOnshore:
+		return "onshore"
+	default:
+		return "unknown"
+	}
+}
+
+// calmWindSpeed is the wind speed in kilometers per hour below which a wind is
+// considered calm.
+const calmWindSpeed = 1.0
+
+// IsCalm checks if the wind is calm, that is its speed is below 1 km/h. The
+// direction of a calm wind is meaningless, and www.surf-forecast.com displays
+// neither an arrow nor a compass point for it, so its DirectionToInDegrees is 0
+// and its DirectionFromInCompassPoints might be empty.
+func (w Wind) IsCalm() bool {
+	return w.SpeedInKilometersPerHour < calmWindSpeed
+}
+
+// compassPointTolerance is the maximum angle between a direction and a compass
+// point for the compass point to be considered the closest one to the direction.
+const compassPointTolerance = 360.0 / 16 / 2
+
+// windDirectionsAgree checks if the given compass point the wind is blowing from
+// is the closest one to the given direction in degrees the wind is blowing to.
+// True is returned when the compass point is unknown since there is nothing to
+// check it against.
+func windDirectionsAgree(degreesTo float64, compassPointFrom string) bool {
+	degreesFrom, ok := ParseCompassPoint(compassPointFrom).Degrees()
+	if !ok {
+		return true
+	}
+
+	diff := math.Abs(math.Mod(degreesTo+180, 360) - degreesFrom)
+	if diff > 180 {
+		diff = 360 - diff
+	}
+
+	return diff <= compassPointTolerance
+}
+
+// smoothingWindow is the number of adjacent hours whose wind directions are
+// averaged by SmoothedWind on each side of an hour.
+const smoothingWindow = 1
+
+// SmoothedWind returns the winds of the day's hourly forecasts with their
+// DirectionToInDegrees smoothed by a moving average over each hour and its
+// neighbouring hours, which removes the jitter of a few degrees caused by the
+// rounding of the site's wind arrows. The directions are averaged as angles, so
+// for instance 350 and 10 degrees average to 0 degrees rather than 180. Calm winds
+// are neither smoothed nor taken into account, since their directions are
+// meaningless, and all other fields are returned as they were scraped. The hourly
+// forecasts themselves are left untouched.
+func (d DailyForecast) SmoothedWind() []Wind {
+	winds := make([]Wind, len(d.Hourly))
+	for i, h := range d.Hourly {
+		winds[i] = h.Wind
+		if h.Wind.IsCalm() {
+			continue
+		}
+
+		var sin, cos float64
+		for j := i - smoothingWindow; j <= i+smoothingWindow; j++ {
+			if j < 0 || j >= len(d.Hourly) || d.Hourly[j].Wind.IsCalm() {
+				continue
+			}
+
+			radians := d.Hourly[j].Wind.DirectionToInDegrees * math.Pi / 180
+			sin += math.Sin(radians)
+			cos += math.Cos(radians)
+		}
+
+		// Opposite directions cancel each other out, in which case there is no
+		// meaningful average.
+		if math.Hypot(sin, cos) < 1e-9 {
+			continue
+		}
+
+		degrees := math.Atan2(sin, cos) * 180 / math.Pi
+		winds[i].DirectionToInDegrees = math.Mod(degrees+360, 360)
+	}
+	return winds
+}
@@ -0,0 +1,76 @@
+package surfforecast
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDailyForecast_SmoothedWind(t *testing.T) {
+	hours := func(winds ...Wind) DailyForecast {
+		var d DailyForecast
+		for _, w := range winds {
+			d.Hourly = append(d.Hourly, HourlyForecast{Wind: w})
+		}
+		return d
+	}
+	wind := func(speed, degrees float64) Wind {
+		return Wind{SpeedInKilometersPerHour: speed, DirectionToInDegrees: degrees}
+	}
+
+	tests := []struct {
+		name string
+		day  DailyForecast
+		want []float64
+	}{
+		{
+			name: "steady",
+			day:  hours(wind(10, 90), wind(10, 90), wind(10, 90)),
+			want: []float64{90, 90, 90},
+		},
+		{
+			name: "jitter",
+			day:  hours(wind(10, 80), wind(10, 100), wind(10, 90)),
+			want: []float64{90, 90, 95},
+		},
+		{
+			name: "across north",
+			day:  hours(wind(10, 350), wind(10, 10)),
+			want: []float64{0, 0},
+		},
+		{
+			name: "across north leaning west",
+			day:  hours(wind(10, 340), wind(10, 350), wind(10, 0)),
+			want: []float64{345, 350, 355},
+		},
+		{
+			name: "calm winds skipped",
+			day:  hours(wind(10, 80), wind(0, 270), wind(10, 100)),
+			want: []float64{80, 270, 100},
+		},
+		{
+			name: "opposite directions kept",
+			day:  hours(wind(10, 0), wind(10, 180)),
+			want: []float64{0, 180},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.day.SmoothedWind()
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d winds, want %d", len(got), len(tt.want))
+			}
+			for i, w := range got {
+				if math.Abs(w.DirectionToInDegrees-tt.want[i]) > 1e-9 {
+					t.Errorf("hour %d: got %v, want %v", i, w.DirectionToInDegrees, tt.want[i])
+				}
+				if w.DirectionToInDegrees < 0 || w.DirectionToInDegrees >= 360 {
+					t.Errorf("hour %d: %v is outside of [0, 360)", i, w.DirectionToInDegrees)
+				}
+				if w.SpeedInKilometersPerHour != tt.day.Hourly[i].Wind.SpeedInKilometersPerHour {
+					t.Errorf("hour %d: speed has changed", i)
+				}
+			}
+		})
+	}
+}